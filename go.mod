@@ -4,6 +4,10 @@ go 1.12
 
 require (
 	github.com/chnsz/golangsdk v0.0.0-20211129061956-055d0ed2e3f8
+	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
+	github.com/hashicorp/go-multierror v1.0.0
+	github.com/hashicorp/terraform-plugin-go v0.3.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.7.0
 	github.com/huaweicloud/terraform-provider-huaweicloud v1.31.0
+	github.com/mitchellh/go-homedir v1.1.0
 )
@@ -0,0 +1,290 @@
+package sbercloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const redactedValue = "***"
+
+// sensitiveHeaders are the request headers whose values are never written to the API log.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"X-Auth-Token",
+	"X-Subject-Token",
+	"X-Security-Token",
+}
+
+// sensitiveFields are the body fields whose values are never written to the API log,
+// they are compared in lower case and without "_" and "-".
+var sensitiveFields = map[string]bool{
+	"adminpass":     true,
+	"secretkey":     true,
+	"secret":        true,
+	"sk":            true,
+	"token":         true,
+	"securitytoken": true,
+	"privatekey":    true,
+	"userdata":      true,
+}
+
+// sensitiveQueryParams are the query parameters whose values are never written to the API log,
+// in addition to the sensitive fields, they are compared like the fields.
+var sensitiveQueryParams = map[string]bool{
+	"signature":         true,
+	"xauthtoken":        true,
+	"xsecuritytoken":    true,
+	"xobssecuritytoken": true,
+	"xamzsignature":     true,
+	"xamzsecuritytoken": true,
+}
+
+// apiLogEntry is a line of the API log.
+type apiLogEntry struct {
+	Time      string            `json:"time"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Service   string            `json:"service"`
+	Status    int               `json:"status,omitempty"`
+	LatencyMs int64             `json:"latency_ms"`
+	RequestID string            `json:"request_id,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Body      json.RawMessage   `json:"body,omitempty"`
+	BodySize  int               `json:"body_size,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// apiLog writes the API requests to a file, one JSON object per line.
+type apiLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// apiLogs are the API logs opened by the provider, keyed by the absolute path of the file.
+// The provider is configured again for each provider block and each Terraform command
+// of the plugin process, which share the file handle of a path, so that no handle is leaked
+// and the lines of a file are never interleaved. The handles are closed by the process exit.
+var (
+	apiLogsMu sync.Mutex
+	apiLogs   = make(map[string]*apiLog)
+)
+
+// openAPILog returns the API log of the api_log block, or nil if the block is not set.
+func openAPILog(d *schema.ResourceData) (*apiLog, error) {
+	raw := d.Get("api_log").([]interface{})
+	if len(raw) == 0 || raw[0] == nil {
+		return nil, nil
+	}
+
+	block := raw[0].(map[string]interface{})
+	path := block["path"].(string)
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening the API log %s: %s", path, err)
+	}
+
+	apiLogsMu.Lock()
+	defer apiLogsMu.Unlock()
+	if l, ok := apiLogs[absPath]; ok {
+		return l, nil
+	}
+
+	file, err := os.OpenFile(absPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening the API log %s: %s", path, err)
+	}
+	l := &apiLog{file: file}
+	apiLogs[absPath] = l
+	return l, nil
+}
+
+func (l *apiLog) write(entry *apiLogEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[WARN] failed to encode the API log entry of %s %s: %s", entry.Method, entry.URL, err)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		log.Printf("[WARN] failed to write the API log: %s", err)
+	}
+}
+
+// installAPILog records the requests of the clients in the API log. The clients are
+// authenticated when they are created by the huaweicloud provider, which builds their
+// transport itself, so the IAM requests of the authentication and of the discovery of
+// the project and account IDs are sent before the API log is installed and are not recorded.
+func installAPILog(c *config.Config, services *serviceHosts, apiLog *apiLog) {
+	for _, client := range []*golangsdk.ProviderClient{c.HwClient, c.DomainClient} {
+		if client == nil {
+			continue
+		}
+		client.HTTPClient.Transport = &apiLogRoundTripper{
+			rt:       client.HTTPClient.Transport,
+			services: services,
+			log:      apiLog,
+		}
+	}
+}
+
+// apiLogRoundTripper records each request, including the retries, in the API log.
+type apiLogRoundTripper struct {
+	rt       http.RoundTripper
+	services *serviceHosts
+	log      *apiLog
+}
+
+func (t *apiLogRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	entry := &apiLogEntry{
+		Method:  req.Method,
+		URL:     redactURL(req.URL),
+		Service: t.services.service(req.URL.Host),
+		Headers: redactHeaders(req.Header),
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > 0 {
+		if redacted, ok := redactBody(body); ok {
+			entry.Body = redacted
+		} else {
+			entry.BodySize = len(body)
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.rt.RoundTrip(req)
+	entry.Time = start.UTC().Format(time.RFC3339Nano)
+	entry.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+		entry.RequestID = resp.Header.Get("X-Request-Id")
+		if entry.RequestID == "" {
+			entry.RequestID = resp.Header.Get("X-Openstack-Request-Id")
+		}
+	}
+
+	t.log.write(entry)
+	return resp, err
+}
+
+// readRequestBody returns the body of the request, which can still be sent afterwards.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return ioutil.ReadAll(body)
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key := range header {
+		headers[key] = header.Get(key)
+	}
+	for _, key := range sensitiveHeaders {
+		if _, ok := headers[http.CanonicalHeaderKey(key)]; ok {
+			headers[http.CanonicalHeaderKey(key)] = redactedValue
+		}
+	}
+	return headers
+}
+
+// redactURL returns the URL with the values of the sensitive query parameters masked,
+// the order and the encoding of the other parameters are kept.
+func redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+
+	params := strings.Split(u.RawQuery, "&")
+	for i, param := range params {
+		key := strings.SplitN(param, "=", 2)[0]
+		if name, err := url.QueryUnescape(key); err == nil && isSensitiveQueryParam(name) {
+			params[i] = key + "=" + redactedValue
+		}
+	}
+
+	redacted := *u
+	redacted.RawQuery = strings.Join(params, "&")
+	return redacted.String()
+}
+
+func isSensitiveQueryParam(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	return sensitiveQueryParams[normalized] || isSensitiveField(key)
+}
+
+// redactBody returns the JSON body with the values of the sensitive fields masked,
+// it returns false if the body is not JSON.
+func redactBody(body []byte) (json.RawMessage, bool) {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, false
+	}
+
+	redacted, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return nil, false
+	}
+	return redacted, true
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if isSensitiveField(key) {
+				value[key] = redactedValue
+			} else {
+				value[key] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+	return v
+}
+
+func isSensitiveField(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	return sensitiveFields[normalized] || strings.Contains(normalized, "password") ||
+		strings.HasSuffix(normalized, "secretkey")
+}
@@ -0,0 +1,215 @@
+package sbercloud
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func readTestAPILog(t *testing.T, path string) []map[string]interface{} {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer file.Close()
+
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("expected a JSON object per line, got %q: %s", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestRedactBody(t *testing.T) {
+	body := `{"server":{"name":"web","adminPass":"p1","user_data":"c2VjcmV0",` +
+		`"metadata":{"admin_pass":"p2"}},"users":[{"name":"u","password":"p3","new_password":"p4"}],` +
+		`"credential":{"access_key":"ak","secret_key":"sk"}}`
+
+	redacted, ok := redactBody([]byte(body))
+	if !ok {
+		t.Fatalf("expected the JSON body to be redacted")
+	}
+
+	for _, secret := range []string{"p1", "p2", "p3", "p4", "c2VjcmV0", `"sk"`} {
+		if strings.Contains(string(redacted), secret) {
+			t.Fatalf("expected %s to be masked, got %s", secret, redacted)
+		}
+	}
+	for _, value := range []string{`"name":"web"`, `"name":"u"`, `"access_key":"ak"`} {
+		if !strings.Contains(string(redacted), value) {
+			t.Fatalf("expected %s to be kept, got %s", value, redacted)
+		}
+	}
+
+	if _, ok := redactBody([]byte("plain text")); ok {
+		t.Fatalf("expected the body which is not JSON to be omitted")
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected string
+	}{
+		{query: "", expected: ""},
+		{query: "limit=10&marker=a%2Fb", expected: "limit=10&marker=a%2Fb"},
+		{query: "X-Auth-Token=t1&nocatalog=1", expected: "X-Auth-Token=***&nocatalog=1"},
+		{
+			query:    "AccessKeyId=ak&Expires=1&Signature=s1&x-obs-security-token=t2",
+			expected: "AccessKeyId=ak&Expires=1&Signature=***&x-obs-security-token=***",
+		},
+		{query: "token=t3&secret_key=s2", expected: "token=***&secret_key=***"},
+	}
+
+	for _, tc := range cases {
+		u := &url.URL{Scheme: "https", Host: "obs.ru-moscow-1.hc.sbercloud.ru", Path: "/bucket/key", RawQuery: tc.query}
+		expected := &url.URL{Scheme: "https", Host: u.Host, Path: u.Path, RawQuery: tc.expected}
+		if got := redactURL(u); got != expected.String() {
+			t.Fatalf("expected %s, got %s", expected, got)
+		}
+	}
+}
+
+func TestAPILogRoundTripper(t *testing.T) {
+	server := newTestFlakyServer(t, 503)
+	path := filepath.Join(t.TempDir(), "api.jsonl")
+	apiLog, err := openAPILog(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_log": []interface{}{
+			map[string]interface{}{
+				"path": path,
+			},
+		},
+	}))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	client := &http.Client{
+		Transport: &apiLogRoundTripper{rt: http.DefaultTransport, services: &serviceHosts{}, log: apiLog},
+	}
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodPost, server.URL+"/v1/servers",
+			strings.NewReader(`{"name":"web","password":"secret"}`))
+		req.Header.Set("X-Auth-Token", "token-value")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	for _, body := range server.requests() {
+		if body != `{"name":"web","password":"secret"}` {
+			t.Fatalf("expected the request body to be sent unchanged, got %s", body)
+		}
+	}
+
+	entries := readTestAPILog(t, path)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry["method"] != "POST" || entry["url"] != server.URL+"/v1/servers" || entry["status"] != 503.0 {
+		t.Fatalf("unexpected entry: %v", entry)
+	}
+	if entry["service"] != "127" {
+		t.Fatalf("expected the service to be named after the host, got %v", entry["service"])
+	}
+	if _, ok := entry["latency_ms"]; !ok {
+		t.Fatalf("expected the latency in %v", entry)
+	}
+	if body := entry["body"].(map[string]interface{}); body["password"] != redactedValue || body["name"] != "web" {
+		t.Fatalf("expected the password to be masked, got %v", body)
+	}
+	if headers := entry["headers"].(map[string]interface{}); headers["X-Auth-Token"] != redactedValue {
+		t.Fatalf("expected the token to be masked, got %v", headers)
+	}
+	if entries[1]["status"] != 200.0 {
+		t.Fatalf("expected the second request to be logged, got %v", entries[1])
+	}
+}
+
+func TestOpenAPILog_samePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.jsonl")
+	raw := map[string]interface{}{
+		"api_log": []interface{}{
+			map[string]interface{}{
+				"path": path,
+			},
+		},
+	}
+
+	first, err := openAPILog(schema.TestResourceDataRaw(t, Provider().Schema, raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	second, err := openAPILog(schema.TestResourceDataRaw(t, Provider().Schema, raw))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if first != second {
+		t.Fatalf("expected the provider configured again to reuse the API log of %s", path)
+	}
+}
+
+func TestProvider_apiLog(t *testing.T) {
+	iam := newTestIAMServer(t)
+	ecs := newTestFlakyServer(t)
+	path := filepath.Join(t.TempDir(), "api.jsonl")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "automation-ak",
+		"secret_key":  "automation-sk",
+		"project_id":  testIAMProjectID,
+		"domain_id":   testIAMDomainID,
+		"max_retries": 0,
+		"api_log": []interface{}{
+			map[string]interface{}{
+				"path": path,
+			},
+		},
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+			"ecs": ecs.URL,
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	client, err := meta.(*config.Config).ComputeV1Client("ru-moscow-1")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, err := client.Get(client.ServiceURL("cloudservers", "detail"), nil, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	entries := readTestAPILog(t, path)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	if entries[0]["service"] != "ecs" || entries[0]["method"] != "GET" {
+		t.Fatalf("unexpected entry: %v", entries[0])
+	}
+	if headers := entries[0]["headers"].(map[string]interface{}); headers["Authorization"] != redactedValue {
+		t.Fatalf("expected the signature to be masked, got %v", headers)
+	}
+}
@@ -0,0 +1,406 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/apigw/apis"
+	"github.com/chnsz/golangsdk/openstack/apigw/groups"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// the APIs of a shared gateway group are listed by pages of this size
+var sharedAPIPageSize = 100
+
+// DataSourceAPIGatewayAPIs exports the APIs of a group of the shared gateway with the arguments
+// of sbercloud_apig_api, so that they can be recreated on a dedicated instance.
+func DataSourceAPIGatewayAPIs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAPIGatewayAPIsRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"group_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"group_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"apis": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"request_protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"request_method": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"request_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"matching": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"security_authentication": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cors": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"body_description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"success_response": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"failure_response": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"request_params": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"required": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"location": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"maximum": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"minimum": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"example": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"default": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"description": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"backend_params": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"location": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"value": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"description": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"web": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"path": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"backend_address": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"request_method": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"request_protocol": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"timeout": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"func_graph": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"function_urn": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"version": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"timeout": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"invocation_type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"mock": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"response": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// listSharedAPIIDs returns the IDs of the APIs of a shared gateway group,
+// the APIs are not listed by the apigw package of golangsdk.
+func listSharedAPIIDs(client *golangsdk.ServiceClient, groupID, name string) ([]string, error) {
+	ids := make([]string, 0)
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s?group_id=%s&page_no=%d&page_size=%d",
+			client.ServiceURL("apis"), groupID, page, sharedAPIPageSize)
+		var resp struct {
+			Total int                `json:"total"`
+			APIs  []apis.ApiInstance `json:"apis"`
+		}
+		_, err := client.Get(url, &resp, &golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, api := range resp.APIs {
+			if name == "" || api.Name == name {
+				ids = append(ids, api.Id)
+			}
+		}
+		if len(resp.APIs) < sharedAPIPageSize || page*sharedAPIPageSize >= resp.Total {
+			return ids, nil
+		}
+	}
+}
+
+// flattenSharedAPI converts an API of the shared gateway to the arguments of sbercloud_apig_api.
+func flattenSharedAPI(api *apis.ApiInstance) map[string]interface{} {
+	apiTypes := map[int]string{
+		1: "Public",
+		2: "Private",
+	}
+	matchings := map[string]string{
+		"SWA":    "Prefix",
+		"NORMAL": "Exact",
+	}
+
+	requestParams := make([]map[string]interface{}, 0, len(api.ReqParams))
+	for _, param := range api.ReqParams {
+		// the limits of a string parameter are the limits of its length
+		maximum, minimum := param.MaxSize, param.MinSize
+		if param.Type == "NUMBER" {
+			maximum, minimum = param.MaxNum, param.MinNum
+		}
+		requestParams = append(requestParams, map[string]interface{}{
+			"name":        param.Name,
+			"required":    param.Required == 1,
+			"location":    param.Location,
+			"type":        param.Type,
+			"maximum":     maximum,
+			"minimum":     minimum,
+			"example":     param.SampleValue,
+			"default":     param.DefaultValue,
+			"description": param.Remark,
+		})
+	}
+
+	backendParams := make([]map[string]interface{}, 0, len(api.BackendParams))
+	for _, param := range api.BackendParams {
+		backendParams = append(backendParams, map[string]interface{}{
+			"type":        param.Origin,
+			"name":        param.Name,
+			"location":    param.Location,
+			"value":       param.Value,
+			"description": param.Remark,
+		})
+	}
+
+	web := make([]map[string]interface{}, 0, 1)
+	funcGraph := make([]map[string]interface{}, 0, 1)
+	mock := make([]map[string]interface{}, 0, 1)
+	switch api.BackendType {
+	case "HTTP":
+		web = append(web, map[string]interface{}{
+			"path":             api.BackendInfo.Uri,
+			"backend_address":  api.BackendInfo.UrlDomain,
+			"request_method":   api.BackendInfo.Method,
+			"request_protocol": api.BackendInfo.Protocol,
+			"timeout":          api.BackendInfo.Timeout,
+		})
+	case "FUNCTION":
+		funcGraph = append(funcGraph, map[string]interface{}{
+			"function_urn":    api.FunctionInfo.FunctionUrn,
+			"version":         api.FunctionInfo.Version,
+			"timeout":         api.FunctionInfo.Timeout,
+			"invocation_type": api.FunctionInfo.InvocationType,
+		})
+	case "MOCK":
+		mock = append(mock, map[string]interface{}{
+			"response": api.MockInfo.ResultContent,
+		})
+	}
+
+	return map[string]interface{}{
+		"id":                      api.Id,
+		"name":                    api.Name,
+		"type":                    apiTypes[api.Type],
+		"description":             api.Remark,
+		"request_protocol":        api.ReqProtocol,
+		"request_method":          api.ReqMethod,
+		"request_path":            api.ReqUri,
+		"matching":                matchings[api.MatchMode],
+		"security_authentication": api.AuthType,
+		"cors":                    api.Cors,
+		"body_description":        api.BodyRemark,
+		"success_response":        api.ResultNormalSample,
+		"failure_response":        api.ResultFailureSample,
+		"request_params":          requestParams,
+		"backend_params":          backendParams,
+		"web":                     web,
+		"func_graph":              funcGraph,
+		"mock":                    mock,
+	}
+}
+
+func dataSourceAPIGatewayAPIsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	client, err := config.ApiGatewayV1Client(region)
+	if err != nil {
+		return diag.Errorf("Error creating SberCloud api gateway client: %s", err)
+	}
+
+	groupID := d.Get("group_id").(string)
+	group, err := groups.Get(client, groupID).Extract()
+	if err != nil {
+		return diag.Errorf("Error querying the api gateway group (%s): %s", groupID, err)
+	}
+
+	ids, err := listSharedAPIIDs(client, groupID, d.Get("name").(string))
+	if err != nil {
+		return diag.Errorf("Error listing the apis of the api gateway group (%s): %s", groupID, err)
+	}
+
+	// the list of the APIs lacks some of their details, so every API is queried
+	apiList := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		api, err := apis.Get(client, id).Extract()
+		if err != nil {
+			return diag.Errorf("Error querying the api gateway api (%s): %s", id, err)
+		}
+		apiList = append(apiList, flattenSharedAPI(api))
+	}
+
+	d.SetId(groupID)
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("group_name", group.Name),
+		d.Set("group_description", group.Remark),
+		d.Set("apis", apiList),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting api gateway apis attributes: %s", err)
+	}
+
+	return nil
+}
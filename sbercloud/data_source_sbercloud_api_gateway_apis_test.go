@@ -0,0 +1,323 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccAPIGatewayAPIsDataSource_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	dataSourceName := "data.sbercloud_api_gateway_apis.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIGatewayAPIsDataSource_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "group_id",
+						"sbercloud_api_gateway_group.test", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "group_name", rName),
+					resource.TestCheckResourceAttr(dataSourceName, "apis.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "apis.0.name", rName),
+					resource.TestCheckResourceAttr(dataSourceName, "apis.0.type", "Public"),
+					resource.TestCheckResourceAttr(dataSourceName, "apis.0.request_method", "GET"),
+					resource.TestCheckResourceAttr(dataSourceName, "apis.0.request_path", "/test/path"),
+					resource.TestCheckResourceAttr(dataSourceName, "apis.0.matching", "Exact"),
+					resource.TestCheckResourceAttr(dataSourceName, "apis.0.web.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "apis.0.web.0.backend_address", "www.example.com"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAPIGatewayAPIsDataSource_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_api_gateway_group" "test" {
+  name        = "%[1]s"
+  description = "created by acc test"
+}
+
+resource "sbercloud_api_gateway_api" "test" {
+  group_id                 = sbercloud_api_gateway_group.test.id
+  name                     = "%[1]s"
+  description              = "created by acc test"
+  tags                     = ["tag1", "tag2"]
+  visibility               = 2
+  auth_type                = "IAM"
+  backend_type             = "HTTP"
+  request_protocol         = "HTTPS"
+  request_method           = "GET"
+  request_uri              = "/test/path"
+  example_success_response = "this is a successful response"
+
+  http_backend {
+    protocol   = "HTTPS"
+    method     = "GET"
+    uri        = "/web/test"
+    url_domain = "www.example.com"
+    timeout    = 10000
+  }
+}
+
+data "sbercloud_api_gateway_apis" "test" {
+  group_id = sbercloud_api_gateway_api.test.group_id
+}
+`, rName)
+}
+
+// testSharedAPIGServer is a local stand-in for the group and API queries of the shared API gateway.
+type testSharedAPIGServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	apis    []map[string]interface{}
+	queries []string
+}
+
+func newTestSharedAPIGServer(t *testing.T) *testSharedAPIGServer {
+	s := &testSharedAPIGServer{
+		apis: []map[string]interface{}{
+			{
+				"id":                   "api-web",
+				"name":                 "web",
+				"group_id":             "group-id",
+				"type":                 1,
+				"req_protocol":         "HTTPS",
+				"req_method":           "GET",
+				"req_uri":              "/users/{id}",
+				"auth_type":            "APP",
+				"match_mode":           "NORMAL",
+				"backend_type":         "HTTP",
+				"remark":               "the web API",
+				"cors":                 true,
+				"body_remark":          "no body",
+				"result_normal_sample": "ok",
+				"req_params": []map[string]interface{}{
+					{"name": "id", "type": "NUMBER", "location": "PATH", "required": 1, "min_num": 1, "max_num": 100},
+					{"name": "q", "type": "STRING", "location": "QUERY", "required": 2, "min_size": 2, "max_size": 20,
+						"sample_value": "foo", "default_value": "bar"},
+				},
+				"backend_params": []map[string]interface{}{
+					{"name": "user", "location": "PATH", "origin": "REQUEST", "value": "id"},
+					{"name": "X-Source", "location": "HEADER", "origin": "CONSTANT", "value": "apig"},
+				},
+				"backend_api": map[string]interface{}{
+					"req_protocol": "HTTP",
+					"req_method":   "POST",
+					"req_uri":      "/backend/{user}",
+					"timeout":      5000,
+					"url_domain":   "backend.example.com",
+				},
+			},
+			{
+				"id":           "api-function",
+				"name":         "function",
+				"group_id":     "group-id",
+				"type":         2,
+				"req_protocol": "HTTPS",
+				"req_method":   "POST",
+				"req_uri":      "/functions",
+				"auth_type":    "IAM",
+				"match_mode":   "SWA",
+				"backend_type": "FUNCTION",
+				"func_info": map[string]interface{}{
+					"function_urn":    "urn:fss:ru-moscow-1:project:function:default:test",
+					"invocation_type": "sync",
+					"timeout":         3000,
+					"version":         "latest",
+				},
+			},
+			{
+				"id":           "api-mock",
+				"name":         "mock",
+				"group_id":     "group-id",
+				"type":         1,
+				"req_protocol": "HTTP",
+				"req_method":   "GET",
+				"req_uri":      "/mock",
+				"auth_type":    "NONE",
+				"match_mode":   "NORMAL",
+				"backend_type": "MOCK",
+				"mock_info": map[string]interface{}{
+					"result_content": "{\"message\": \"mocked\"}",
+				},
+			},
+			{
+				"id":           "api-other",
+				"name":         "other",
+				"group_id":     "other-group-id",
+				"type":         1,
+				"req_protocol": "HTTP",
+				"req_method":   "GET",
+				"req_uri":      "/other",
+				"auth_type":    "NONE",
+				"match_mode":   "NORMAL",
+				"backend_type": "MOCK",
+			},
+		},
+	}
+
+	prefix := "/v1.0/apigw/"
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+		parts := strings.Split(path, "/")
+		switch {
+		case r.Method == http.MethodGet && path == "api-groups/group-id":
+			writeTestJSON(w, map[string]interface{}{
+				"id":     "group-id",
+				"name":   "shared_group",
+				"remark": "the shared group",
+				"status": 1,
+			})
+		case r.Method == http.MethodGet && path == "apis":
+			s.queries = append(s.queries, r.URL.RawQuery)
+			query := r.URL.Query()
+			groupAPIs := make([]map[string]interface{}, 0)
+			for _, api := range s.apis {
+				if api["group_id"] == query.Get("group_id") {
+					// the list only returns a summary of the APIs
+					groupAPIs = append(groupAPIs, map[string]interface{}{"id": api["id"], "name": api["name"]})
+				}
+			}
+			pageNo, _ := strconv.Atoi(query.Get("page_no"))
+			pageSize, _ := strconv.Atoi(query.Get("page_size"))
+			start, end := (pageNo-1)*pageSize, pageNo*pageSize
+			if start > len(groupAPIs) {
+				start = len(groupAPIs)
+			}
+			if end > len(groupAPIs) {
+				end = len(groupAPIs)
+			}
+			writeTestJSON(w, map[string]interface{}{
+				"total": len(groupAPIs),
+				"size":  end - start,
+				"apis":  groupAPIs[start:end],
+			})
+		case r.Method == http.MethodGet && len(parts) == 2 && parts[0] == "apis":
+			for _, api := range s.apis {
+				if api["id"] == parts[1] {
+					writeTestJSON(w, api)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testSharedAPIGServer) endpoints() map[string]interface{} {
+	return map[string]interface{}{
+		"apig": s.URL + "/",
+	}
+}
+
+func TestDataSourceAPIGatewayAPIs(t *testing.T) {
+	apig := newTestSharedAPIGServer(t)
+	meta := testProviderMeta(t, apig.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceAPIGatewayAPIs().Schema, map[string]interface{}{
+		"group_id": "group-id",
+	})
+	expectNoWarnings(t, dataSourceAPIGatewayAPIsRead(context.Background(), d, meta))
+
+	if d.Id() != "group-id" || d.Get("group_name") != "shared_group" || d.Get("group_description") != "the shared group" {
+		t.Fatalf("unexpected group: id %q, name %q, description %q", d.Id(), d.Get("group_name"), d.Get("group_description"))
+	}
+	if n := d.Get("apis.#").(int); n != 3 {
+		t.Fatalf("expected 3 APIs, got %d", n)
+	}
+
+	web := d.Get("apis.0").(map[string]interface{})
+	if web["type"] != "Public" || web["matching"] != "Exact" || web["request_path"] != "/users/{id}" {
+		t.Fatalf("unexpected web API: %v", web)
+	}
+	if web["security_authentication"] != "APP" || web["cors"] != true || web["success_response"] != "ok" {
+		t.Fatalf("unexpected web API options: %v", web)
+	}
+	if d.Get("apis.0.request_params.0.required") != true || d.Get("apis.0.request_params.0.maximum") != 100 {
+		t.Fatalf("expected the limits of the number parameter, got %v", d.Get("apis.0.request_params.0"))
+	}
+	if d.Get("apis.0.request_params.1.required") != false || d.Get("apis.0.request_params.1.maximum") != 20 ||
+		d.Get("apis.0.request_params.1.example") != "foo" || d.Get("apis.0.request_params.1.default") != "bar" {
+		t.Fatalf("expected the length limits of the string parameter, got %v", d.Get("apis.0.request_params.1"))
+	}
+	if d.Get("apis.0.backend_params.0.type") != "REQUEST" || d.Get("apis.0.backend_params.1.type") != "CONSTANT" {
+		t.Fatalf("unexpected backend parameters: %v", d.Get("apis.0.backend_params"))
+	}
+	if d.Get("apis.0.web.0.backend_address") != "backend.example.com" || d.Get("apis.0.web.0.path") != "/backend/{user}" ||
+		d.Get("apis.0.web.0.request_method") != "POST" || d.Get("apis.0.web.0.timeout") != 5000 {
+		t.Fatalf("unexpected web backend: %v", d.Get("apis.0.web"))
+	}
+	if d.Get("apis.0.func_graph.#") != 0 || d.Get("apis.0.mock.#") != 0 {
+		t.Fatalf("expected only the web backend")
+	}
+
+	if d.Get("apis.1.type") != "Private" || d.Get("apis.1.matching") != "Prefix" {
+		t.Fatalf("unexpected function API: %v", d.Get("apis.1"))
+	}
+	if d.Get("apis.1.func_graph.0.version") != "latest" || d.Get("apis.1.func_graph.0.timeout") != 3000 ||
+		d.Get("apis.1.web.#") != 0 {
+		t.Fatalf("unexpected function backend: %v", d.Get("apis.1.func_graph"))
+	}
+
+	if d.Get("apis.2.mock.0.response") != "{\"message\": \"mocked\"}" || d.Get("apis.2.web.#") != 0 {
+		t.Fatalf("unexpected mock backend: %v", d.Get("apis.2.mock"))
+	}
+}
+
+func TestDataSourceAPIGatewayAPIs_pages(t *testing.T) {
+	pageSize := sharedAPIPageSize
+	sharedAPIPageSize = 2
+	defer func() { sharedAPIPageSize = pageSize }()
+
+	apig := newTestSharedAPIGServer(t)
+	meta := testProviderMeta(t, apig.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceAPIGatewayAPIs().Schema, map[string]interface{}{
+		"group_id": "group-id",
+		"name":     "mock",
+	})
+	expectNoWarnings(t, dataSourceAPIGatewayAPIsRead(context.Background(), d, meta))
+
+	if n := d.Get("apis.#").(int); n != 1 || d.Get("apis.0.id") != "api-mock" {
+		t.Fatalf("expected only the mock API, got %v", d.Get("apis"))
+	}
+
+	apig.mu.Lock()
+	defer apig.mu.Unlock()
+	if len(apig.queries) != 2 {
+		t.Fatalf("expected the APIs to be listed by 2 pages, got %v", apig.queries)
+	}
+}
+
+func TestDataSourceAPIGatewayAPIs_groupNotFound(t *testing.T) {
+	apig := newTestSharedAPIGServer(t)
+	meta := testProviderMeta(t, apig.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceAPIGatewayAPIs().Schema, map[string]interface{}{
+		"group_id": "missing-group",
+	})
+	if diags := dataSourceAPIGatewayAPIsRead(context.Background(), d, meta); !diags.HasError() {
+		t.Fatalf("expected the missing group to be reported")
+	}
+}
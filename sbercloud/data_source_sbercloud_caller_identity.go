@@ -0,0 +1,217 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chnsz/golangsdk/openstack/identity/v3.0/credentials"
+	"github.com/chnsz/golangsdk/openstack/identity/v3.0/users"
+	"github.com/chnsz/golangsdk/openstack/identity/v3/domains"
+	"github.com/chnsz/golangsdk/openstack/identity/v3/projects"
+	"github.com/chnsz/golangsdk/openstack/identity/v3/tokens"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func DataSourceCallerIdentity() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCallerIdentityRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"domain_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"domain_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"project_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"project_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"agency_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"agency_domain_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCallerIdentityRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*config.Config)
+	region := config.GetRegion(d)
+
+	domainID, domainName, err := callerDomain(config, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	project, err := callerProject(config, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the user can not be read with all credentials, e.g. the agency token
+	// belongs to the delegating account, so it is reported as a warning
+	var diags diag.Diagnostics
+	userID, userName, err := callerUser(config, region)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unable to read the user of the provider credentials",
+			Detail:   err.Error(),
+		})
+	}
+
+	d.SetId(domainID)
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("domain_id", domainID),
+		d.Set("domain_name", domainName),
+		d.Set("user_id", userID),
+		d.Set("user_name", userName),
+		d.Set("project_id", project.ID),
+		d.Set("project_name", project.Name),
+		d.Set("agency_name", config.AgencyName),
+		d.Set("agency_domain_name", config.AgencyDomainName),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return append(diags, diag.Errorf("error setting caller identity attributes: %s", err)...)
+	}
+
+	return diags
+}
+
+// callerDomain returns the account which the requests of the provider are authorized for.
+func callerDomain(c *config.Config, region string) (string, string, error) {
+	if c.DomainID != "" && c.DomainName != "" && c.AgencyName == "" {
+		return c.DomainID, c.DomainName, nil
+	}
+
+	identityClient, err := c.IdentityV3Client(region)
+	if err != nil {
+		return "", "", fmt.Errorf("error creating IAM client: %s", err)
+	}
+	// ResourceBase: https://iam.{CLOUD}/v3/auth/
+	identityClient.ResourceBase += "auth/"
+
+	allPages, err := domains.List(identityClient, nil).AllPages()
+	if err != nil {
+		return "", "", fmt.Errorf("error querying the account: %s", err)
+	}
+	all, err := domains.ExtractDomains(allPages)
+	if err != nil {
+		return "", "", fmt.Errorf("error extracting the account: %s", err)
+	}
+	if len(all) == 0 {
+		return "", "", fmt.Errorf("the account of the provider credentials was not found")
+	}
+
+	return all[0].ID, all[0].Name, nil
+}
+
+// callerProject returns the project of the region which the requests of the provider are authorized for.
+func callerProject(c *config.Config, region string) (projects.Project, error) {
+	all, err := listAuthProjects(c, region)
+	if err != nil {
+		return projects.Project{}, err
+	}
+
+	c.RPLock.Lock()
+	projectID := c.RegionProjectIDMap[region]
+	c.RPLock.Unlock()
+
+	for _, project := range all {
+		if (projectID != "" && project.ID == projectID) || (projectID == "" && project.Name == region) {
+			return project, nil
+		}
+	}
+	return projects.Project{}, fmt.Errorf("the project of the region %s was not found", region)
+}
+
+// listAuthProjects returns the projects which the provider credentials have access to.
+func listAuthProjects(c *config.Config, region string) ([]projects.Project, error) {
+	identityClient, err := c.IdentityV3Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating IAM client: %s", err)
+	}
+	// ResourceBase: https://iam.{CLOUD}/v3/auth/
+	identityClient.ResourceBase += "auth/"
+
+	allPages, err := projects.List(identityClient, nil).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("error querying the projects: %s", err)
+	}
+	all, err := projects.ExtractProjects(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting the projects: %s", err)
+	}
+	return all, nil
+}
+
+// callerUser returns the IAM user which the provider credentials belong to.
+func callerUser(c *config.Config, region string) (string, string, error) {
+	userID, userName := c.UserID, c.Username
+
+	switch {
+	case c.Token != "":
+		identityClient, err := c.IdentityV3Client(region)
+		if err != nil {
+			return "", "", fmt.Errorf("error creating IAM client: %s", err)
+		}
+		user, err := tokens.Get(identityClient, c.Token).ExtractUser()
+		if err != nil {
+			return "", "", fmt.Errorf("error validating the token: %s", err)
+		}
+		return user.ID, user.Name, nil
+
+	case c.AccessKey != "" && userID == "":
+		iamClient, err := c.IAMV3Client(region)
+		if err != nil {
+			return "", "", fmt.Errorf("error creating IAM client: %s", err)
+		}
+		credential, err := credentials.Get(iamClient, c.AccessKey).Extract()
+		if err != nil {
+			return "", "", fmt.Errorf("error querying the access key: %s", err)
+		}
+		userID = credential.UserID
+	}
+
+	if userID != "" && userName == "" {
+		iamClient, err := c.IAMV3Client(region)
+		if err != nil {
+			return userID, "", fmt.Errorf("error creating IAM client: %s", err)
+		}
+		user, err := users.Get(iamClient, userID).Extract()
+		if err != nil {
+			return userID, "", fmt.Errorf("error querying the user %s: %s", userID, err)
+		}
+		userName = user.Name
+	}
+
+	return userID, userName, nil
+}
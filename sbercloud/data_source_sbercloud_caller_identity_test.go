@@ -0,0 +1,132 @@
+package sbercloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccCallerIdentity_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCallerIdentityConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.sbercloud_caller_identity.current", "domain_id"),
+					resource.TestCheckResourceAttrSet("data.sbercloud_caller_identity.current", "domain_name"),
+					resource.TestCheckResourceAttrSet("data.sbercloud_caller_identity.current", "user_id"),
+					resource.TestCheckResourceAttrSet("data.sbercloud_caller_identity.current", "project_id"),
+					resource.TestCheckResourceAttr("data.sbercloud_caller_identity.current", "project_name", SBC_REGION_NAME),
+				),
+			},
+		},
+	})
+}
+
+const testAccCallerIdentityConfig_basic = `
+data "sbercloud_caller_identity" "current" {}
+`
+
+func testReadCallerIdentity(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, raw), "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, DataSourceCallerIdentity().Schema, map[string]interface{}{})
+	if diags := dataSourceCallerIdentityRead(context.Background(), d, meta); len(diags) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return d
+}
+
+func expectAttributes(t *testing.T, d *schema.ResourceData, expected map[string]string) {
+	for key, value := range expected {
+		if got := d.Get(key).(string); got != value {
+			t.Fatalf("expected %s to be %q, got %q", key, value, got)
+		}
+	}
+}
+
+func TestDataSourceCallerIdentity_accessKey(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	d := testReadCallerIdentity(t, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "automation-ak",
+		"secret_key":  "automation-sk",
+		"max_retries": 0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	expectAttributes(t, d, map[string]string{
+		"region":       "ru-moscow-1",
+		"domain_id":    testIAMDomainID,
+		"domain_name":  testIAMDomainName,
+		"user_id":      testIAMUserID,
+		"user_name":    testIAMUserName,
+		"project_id":   testIAMProjectID,
+		"project_name": "ru-moscow-1",
+		"agency_name":  "",
+	})
+	if !iam.requested("/v3.0/OS-CREDENTIAL/credentials/automation-ak") {
+		t.Fatalf("expected the user to be read from the access key")
+	}
+}
+
+func TestDataSourceCallerIdentity_token(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	d := testReadCallerIdentity(t, map[string]interface{}{
+		"region":       "ru-moscow-1",
+		"auth_url":     iam.URL + "/v3",
+		"token":        "my-token",
+		"account_name": testIAMDomainName,
+		"max_retries":  0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	expectAttributes(t, d, map[string]string{
+		"domain_id":   testIAMDomainID,
+		"domain_name": testIAMDomainName,
+		"user_id":     testIAMUserID,
+		"user_name":   testIAMUserName,
+		"project_id":  testIAMProjectID,
+	})
+	if got := iam.header("X-Subject-Token"); got != "my-token" {
+		t.Fatalf("expected the token to be validated, got %q", got)
+	}
+}
+
+func TestDataSourceCallerIdentity_agency(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	d := testReadCallerIdentity(t, map[string]interface{}{
+		"region":             "ru-moscow-1",
+		"auth_url":           iam.URL + "/v3",
+		"access_key":         "automation-ak",
+		"secret_key":         "automation-sk",
+		"account_name":       testIAMDomainName,
+		"agency_name":        "tenant_admin",
+		"agency_domain_name": "tenant-account",
+		"max_retries":        0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	expectAttributes(t, d, map[string]string{
+		"project_id":         testIAMAgencyProjectID,
+		"agency_name":        "tenant_admin",
+		"agency_domain_name": "tenant-account",
+	})
+}
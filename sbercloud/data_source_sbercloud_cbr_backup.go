@@ -0,0 +1,191 @@
+package sbercloud
+
+import (
+	"context"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// cbrBackup is a backup of CBR, the backup of a server has the backups of its volumes as children.
+type cbrBackup struct {
+	ID           string      `json:"id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	CheckpointID string      `json:"checkpoint_id"`
+	VaultID      string      `json:"vault_id"`
+	ResourceID   string      `json:"resource_id"`
+	ResourceName string      `json:"resource_name"`
+	ResourceType string      `json:"resource_type"`
+	ResourceSize int         `json:"resource_size"`
+	ImageType    string      `json:"image_type"`
+	Status       string      `json:"status"`
+	CreatedAt    string      `json:"created_at"`
+	ExpiredAt    string      `json:"expired_at"`
+	Children     []cbrBackup `json:"children"`
+}
+
+// getCBRBackup returns the backup, the backups are not supported by the CBR package of golangsdk.
+func getCBRBackup(client *golangsdk.ServiceClient, id string) (*cbrBackup, error) {
+	var resp struct {
+		Backup cbrBackup `json:"backup"`
+	}
+	_, err := client.Get(client.ServiceURL("backups", id), &resp, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Backup, nil
+}
+
+func DataSourceCBRBackup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCBRBackupRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"checkpoint_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vault_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resource_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resource_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resource_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"image_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expired_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"children": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCBRBackupRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	client, err := config.CbrV3Client(region)
+	if err != nil {
+		return diag.Errorf("Error creating SberCloud CBR v3 client: %s", err)
+	}
+
+	id := d.Get("id").(string)
+	backup, err := getCBRBackup(client, id)
+	if err != nil {
+		return diag.Errorf("Error querying the CBR backup (%s): %s", id, err)
+	}
+
+	children := make([]map[string]interface{}, 0, len(backup.Children))
+	for _, child := range backup.Children {
+		children = append(children, map[string]interface{}{
+			"id":            child.ID,
+			"name":          child.Name,
+			"resource_id":   child.ResourceID,
+			"resource_type": child.ResourceType,
+			"resource_size": child.ResourceSize,
+			"status":        child.Status,
+		})
+	}
+
+	d.SetId(backup.ID)
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("name", backup.Name),
+		d.Set("description", backup.Description),
+		d.Set("checkpoint_id", backup.CheckpointID),
+		d.Set("vault_id", backup.VaultID),
+		d.Set("resource_id", backup.ResourceID),
+		d.Set("resource_name", backup.ResourceName),
+		d.Set("resource_type", backup.ResourceType),
+		d.Set("resource_size", backup.ResourceSize),
+		d.Set("image_type", backup.ImageType),
+		d.Set("status", backup.Status),
+		d.Set("created_at", backup.CreatedAt),
+		d.Set("expired_at", backup.ExpiredAt),
+		d.Set("children", children),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting CBR backup attributes: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccCBRBackupDataSource_basic(t *testing.T) {
+	dataSourceName := "data.sbercloud_cbr_backup.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCBRBackup(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCBRBackupDataSource_basic(SBC_CBR_BACKUP_ID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", SBC_CBR_BACKUP_ID),
+					resource.TestCheckResourceAttrSet(dataSourceName, "vault_id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "resource_id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "resource_type"),
+					resource.TestCheckResourceAttr(dataSourceName, "status", "available"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCBRBackupDataSource_basic(backupID string) string {
+	return fmt.Sprintf(`
+data "sbercloud_cbr_backup" "test" {
+  id = "%s"
+}
+`, backupID)
+}
+
+func TestDataSourceCBRBackup(t *testing.T) {
+	cbr := newTestCBRServer(t)
+	meta := testProviderMeta(t, cbr.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceCBRBackup().Schema, map[string]interface{}{
+		"id": "server-backup",
+	})
+	expectNoWarnings(t, dataSourceCBRBackupRead(context.Background(), d, meta))
+
+	if d.Id() != "server-backup" || d.Get("vault_id") != "vault-server" || d.Get("resource_id") != "server-id" {
+		t.Fatalf("unexpected backup: id %q, vault %q, resource %q", d.Id(), d.Get("vault_id"), d.Get("resource_id"))
+	}
+	if d.Get("resource_type") != "OS::Nova::Server" || d.Get("resource_size") != 50 {
+		t.Fatalf("unexpected resource: %q of %d GB", d.Get("resource_type"), d.Get("resource_size"))
+	}
+	if n := d.Get("children.#").(int); n != 2 {
+		t.Fatalf("expected 2 volume backups, got %d", n)
+	}
+	if d.Get("children.1.id") != "data-backup" || d.Get("children.1.resource_id") != "data-volume" {
+		t.Fatalf("unexpected volume backup: %v", d.Get("children.1"))
+	}
+}
+
+func TestDataSourceCBRBackup_notFound(t *testing.T) {
+	cbr := newTestCBRServer(t)
+	meta := testProviderMeta(t, cbr.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceCBRBackup().Schema, map[string]interface{}{
+		"id": "missing-backup",
+	})
+	if diags := dataSourceCBRBackupRead(context.Background(), d, meta); !diags.HasError() {
+		t.Fatalf("expected the missing backup to be reported")
+	}
+}
@@ -0,0 +1,190 @@
+package sbercloud
+
+import (
+	"context"
+
+	"github.com/chnsz/golangsdk/openstack/cbr/v3/vaults"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func DataSourceCBRVaults() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCBRVaultsRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"server", "disk", "turbo"}, false),
+			},
+			"protection_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"backup", "replication"}, false),
+			},
+			"policy_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enterprise_project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"vaults": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"consistent_level": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protection_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"auto_expand": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"enterprise_project_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"allocated": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"used": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"spec_code": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"storage": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCBRVaultsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	client, err := config.CbrV3Client(region)
+	if err != nil {
+		return diag.Errorf("Error creating SberCloud CBR v3 client: %s", err)
+	}
+
+	listOpts := vaults.ListOpts{
+		Name:                d.Get("name").(string),
+		ObjectType:          d.Get("type").(string),
+		ProtectType:         d.Get("protection_type").(string),
+		PolicyID:            d.Get("policy_id").(string),
+		Status:              d.Get("status").(string),
+		EnterpriseProjectID: d.Get("enterprise_project_id").(string),
+	}
+	allPages, err := vaults.List(client, listOpts).AllPages()
+	if err != nil {
+		return diag.Errorf("Error querying the CBR vaults: %s", err)
+	}
+	all, err := vaults.ExtractVaults(allPages)
+	if err != nil {
+		return diag.Errorf("Error extracting the CBR vaults: %s", err)
+	}
+
+	ids := make([]string, 0, len(*all))
+	result := make([]map[string]interface{}, 0, len(*all))
+	for _, vault := range *all {
+		resourceIDs := make([]string, 0, len(vault.Resources))
+		for _, r := range vault.Resources {
+			resourceIDs = append(resourceIDs, r.ID)
+		}
+
+		ids = append(ids, vault.ID)
+		result = append(result, map[string]interface{}{
+			"id":                    vault.ID,
+			"name":                  vault.Name,
+			"type":                  vault.Billing.ObjectType,
+			"consistent_level":      vault.Billing.ConsistentLevel,
+			"protection_type":       vault.Billing.ProtectType,
+			"size":                  vault.Billing.Size,
+			"auto_expand":           vault.AutoExpand,
+			"enterprise_project_id": vault.EnterpriseProjectID,
+			"resource_ids":          resourceIDs,
+			// the allocated and used capacity are returned in MB
+			"allocated": float64(vault.Billing.Allocated) / 1024,
+			"used":      float64(vault.Billing.Used) / 1024,
+			"spec_code": vault.Billing.SpecCode,
+			"status":    vault.Billing.Status,
+			"storage":   vault.Billing.StorageUnit,
+			"tags":      utils.TagsToMap(vault.Tags),
+		})
+	}
+
+	d.SetId(hashcode.Strings(append([]string{region}, ids...)))
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("vaults", result),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting CBR vaults attributes: %s", err)
+	}
+
+	return nil
+}
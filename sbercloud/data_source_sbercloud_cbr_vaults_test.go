@@ -0,0 +1,95 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccCBRVaultsDataSource_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	dataSourceName := "data.sbercloud_cbr_vaults.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCBRVaultsDataSource_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "vaults.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "vaults.0.id", "sbercloud_cbr_vault.test", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "vaults.0.name", rName),
+					resource.TestCheckResourceAttr(dataSourceName, "vaults.0.type", "disk"),
+					resource.TestCheckResourceAttr(dataSourceName, "vaults.0.size", "50"),
+					resource.TestCheckResourceAttr(dataSourceName, "vaults.0.resource_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "vaults.0.resource_ids.0", "sbercloud_evs_volume.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCBRVaultsDataSource_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_cbr_vaults" "test" {
+  name = sbercloud_cbr_vault.test.name
+  type = "disk"
+}
+`, testAccCBRVault_disk(rName, 50))
+}
+
+func TestDataSourceCBRVaults(t *testing.T) {
+	cbr := newTestCBRServer(t)
+	meta := testProviderMeta(t, cbr.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceCBRVaults().Schema, map[string]interface{}{})
+	expectNoWarnings(t, dataSourceCBRVaultsRead(context.Background(), d, meta))
+
+	if n := d.Get("vaults.#").(int); n != 2 {
+		t.Fatalf("expected 2 vaults, got %d", n)
+	}
+	vault := d.Get("vaults.0").(map[string]interface{})
+	if vault["id"] != "vault-server" || vault["type"] != "server" || vault["size"] != 100 {
+		t.Fatalf("unexpected vault: %v", vault)
+	}
+	if vault["allocated"] != 50.0 || vault["used"] != 2.0 {
+		t.Fatalf("expected the capacity in GB, got allocated %v and used %v", vault["allocated"], vault["used"])
+	}
+	if ids := vault["resource_ids"].([]interface{}); len(ids) != 1 || ids[0] != "server-id" {
+		t.Fatalf("unexpected resource IDs: %v", ids)
+	}
+	if tags := vault["tags"].(map[string]interface{}); tags["foo"] != "bar" {
+		t.Fatalf("unexpected tags: %v", tags)
+	}
+}
+
+func TestDataSourceCBRVaults_filter(t *testing.T) {
+	cbr := newTestCBRServer(t)
+	meta := testProviderMeta(t, cbr.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceCBRVaults().Schema, map[string]interface{}{
+		"type":            "disk",
+		"protection_type": "backup",
+	})
+	expectNoWarnings(t, dataSourceCBRVaultsRead(context.Background(), d, meta))
+
+	if n := d.Get("vaults.#").(int); n != 1 || d.Get("vaults.0.id") != "vault-disk" {
+		t.Fatalf("expected only the disk vault, got %v", d.Get("vaults"))
+	}
+	if d.Get("vaults.0.auto_expand") != true {
+		t.Fatalf("expected auto_expand to be set")
+	}
+
+	cbr.mu.Lock()
+	defer cbr.mu.Unlock()
+	if len(cbr.queries) != 1 || cbr.queries[0] != "object_type=disk&protect_type=backup" {
+		t.Fatalf("unexpected queries: %v", cbr.queries)
+	}
+}
@@ -0,0 +1,50 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCCEAddonTemplateV3DataSource_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCCEAddon(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCEClusterV3Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEAddonTemplateV3DataSource_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.sbercloud_cce_addon_template.metrics_server_test", "spec"),
+					resource.TestCheckResourceAttrSet("data.sbercloud_cce_addon_template.autoscaler_test", "spec"),
+					resource.TestCheckResourceAttrSet("data.sbercloud_cce_addon_template.autoscaler_test",
+						"support_version.0.virtual_machine.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCCEAddonTemplateV3DataSource_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_cce_addon_template" "metrics_server_test" {
+  cluster_id = sbercloud_cce_cluster.test.id
+  name       = "metrics-server"
+  version    = "%s"
+}
+
+data "sbercloud_cce_addon_template" "autoscaler_test" {
+  cluster_id = sbercloud_cce_cluster.test.id
+  name       = "autoscaler"
+  version    = "%s"
+}
+`, testAccCCEClusterV3_basic(rName), SBC_CCE_METRICS_SERVER_VERSION, SBC_CCE_AUTOSCALER_VERSION)
+}
@@ -0,0 +1,100 @@
+package sbercloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
+)
+
+func DataSourceQuotas() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceQuotasRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"services": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(quotaServices, false),
+				},
+			},
+			"quotas": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"used": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"limit": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceQuotasRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+
+	services := quotaServices
+	if v, ok := d.GetOk("services"); ok {
+		services = nil
+		// the services are queried in a stable order
+		for _, service := range quotaServices {
+			if v.(*schema.Set).Contains(service) {
+				services = append(services, service)
+			}
+		}
+	}
+
+	all, err := listQuotas(config, region, services)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	quotas := make([]map[string]interface{}, 0, len(all))
+	for _, quota := range all {
+		quotas = append(quotas, map[string]interface{}{
+			"service": quota.Service,
+			"type":    quota.Type,
+			"used":    quota.Used,
+			"limit":   quota.Limit,
+		})
+	}
+
+	d.SetId(hashcode.Strings(append([]string{region}, services...)))
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("quotas", quotas),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting quotas attributes: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,456 @@
+package sbercloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccQuotas_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQuotasConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.sbercloud_quotas.all", "quotas.#", regexp.MustCompile("[1-9]\\d*")),
+					resource.TestCheckTypeSetElemNestedAttrs("data.sbercloud_quotas.all", "quotas.*", map[string]string{
+						"service": "network",
+						"type":    "vpc",
+					}),
+					resource.TestCheckResourceAttr("data.sbercloud_quotas.evs", "quotas.#", "5"),
+				),
+			},
+		},
+	})
+}
+
+const testAccQuotasConfig_basic = `
+data "sbercloud_quotas" "all" {}
+
+data "sbercloud_quotas" "evs" {
+  services = ["evs"]
+}
+`
+
+// testQuotaServer is a local stand-in for the quota APIs of ECS, VPC and EVS.
+type testQuotaServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	vpcUsed  int
+	requests int
+}
+
+func newTestQuotaServer(t *testing.T) *testQuotaServer {
+	s := &testQuotaServer{vpcUsed: 3}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests++
+
+		switch r.URL.Path {
+		case "/v2.1/" + testIAMProjectID + "/limits":
+			writeTestJSON(w, map[string]interface{}{
+				"limits": map[string]interface{}{
+					"absolute": map[string]interface{}{
+						"maxTotalInstances":     10,
+						"totalInstancesUsed":    10,
+						"maxTotalCores":         40,
+						"totalCoresUsed":        38,
+						"maxTotalRAMSize":       81920,
+						"totalRAMUsed":          79872,
+						"maxServerGroups":       10,
+						"totalServerGroupsUsed": 1,
+					},
+				},
+			})
+		case "/v2.1/" + testIAMProjectID + "/flavors/detail":
+			writeTestJSON(w, map[string]interface{}{
+				"flavors": []map[string]interface{}{
+					{"id": "s6.small.1", "name": "s6.small.1", "vcpus": 1, "ram": 1024},
+					{"id": "s6.xlarge.2", "name": "s6.xlarge.2", "vcpus": 4, "ram": 8192},
+				},
+			})
+		case "/v2.1/" + testIAMProjectID + "/flavors/s6.small.1":
+			writeTestJSON(w, map[string]interface{}{
+				"flavor": map[string]interface{}{"id": "s6.small.1", "name": "s6.small.1", "vcpus": 1, "ram": 1024},
+			})
+		case "/v2.1/" + testIAMProjectID + "/flavors/s6.xlarge.2":
+			writeTestJSON(w, map[string]interface{}{
+				"flavor": map[string]interface{}{"id": "s6.xlarge.2", "name": "s6.xlarge.2", "vcpus": 4, "ram": 8192},
+			})
+		case "/v1/" + testIAMProjectID + "/quotas":
+			writeTestJSON(w, map[string]interface{}{
+				"quotas": map[string]interface{}{
+					"resources": []map[string]interface{}{
+						{"type": "vpc", "used": s.vpcUsed, "quota": 5, "min": 0},
+						{"type": "securityGroup", "used": 12, "quota": 100, "min": 0},
+						{"type": "publicIp", "used": 2, "quota": -1, "min": 0},
+					},
+				},
+			})
+		case "/v2/" + testIAMProjectID + "/os-quota-sets/" + testIAMProjectID:
+			if r.URL.Query().Get("usage") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			usage := func(inUse, limit int) map[string]interface{} {
+				return map[string]interface{}{"in_use": inUse, "limit": limit, "reserved": 0}
+			}
+			writeTestJSON(w, map[string]interface{}{
+				"quota_set": map[string]interface{}{
+					"id":               testIAMProjectID,
+					"volumes":          usage(7, 50),
+					"gigabytes":        usage(900, 1000),
+					"snapshots":        usage(0, 50),
+					"backups":          usage(1, 20),
+					"backup_gigabytes": usage(40, 500),
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testQuotaServer) endpoints() map[string]interface{} {
+	return map[string]interface{}{
+		"ecsv21":   s.URL + "/",
+		"vpc":      s.URL + "/",
+		"volumev2": s.URL + "/",
+	}
+}
+
+func (s *testQuotaServer) requestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func TestDataSourceQuotas(t *testing.T) {
+	quotas := newTestQuotaServer(t)
+	meta := testProviderMeta(t, quotas.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceQuotas().Schema, map[string]interface{}{})
+	if diags := dataSourceQuotasRead(context.Background(), d, meta); len(diags) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var got []string
+	for _, raw := range d.Get("quotas").([]interface{}) {
+		q := raw.(map[string]interface{})
+		got = append(got, q["service"].(string)+"/"+q["type"].(string))
+	}
+	expected := []string{
+		"compute/cores", "compute/instances", "compute/ram", "compute/server_groups",
+		"evs/backup_gigabytes", "evs/backups", "evs/gigabytes", "evs/snapshots", "evs/volumes",
+		"network/publicIp", "network/securityGroup", "network/vpc",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected the quotas %v, got %v", expected, got)
+	}
+
+	instances := d.Get("quotas.1").(map[string]interface{})
+	if instances["used"] != 10 || instances["limit"] != 10 {
+		t.Fatalf("unexpected instances quota: %v", instances)
+	}
+	publicIP := d.Get("quotas.9").(map[string]interface{})
+	if publicIP["used"] != 2 || publicIP["limit"] != -1 {
+		t.Fatalf("unexpected publicIp quota: %v", publicIP)
+	}
+}
+
+func TestDataSourceQuotas_services(t *testing.T) {
+	quotas := newTestQuotaServer(t)
+	meta := testProviderMeta(t, quotas.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceQuotas().Schema, map[string]interface{}{
+		"services": []interface{}{"evs"},
+	})
+	if diags := dataSourceQuotasRead(context.Background(), d, meta); len(diags) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if n := len(d.Get("quotas").([]interface{})); n != 5 {
+		t.Fatalf("expected the 5 EVS quotas, got %d", n)
+	}
+}
+
+// testQuotaProvider configures the provider against the quota stand-in.
+func testQuotaProvider(t *testing.T, quotas *testQuotaServer, checkQuotas bool) *schema.Provider {
+	iam := newTestIAMServer(t)
+	endpoints := quotas.endpoints()
+	endpoints["iam"] = iam.URL
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"region":       "ru-moscow-1",
+		"auth_url":     iam.URL + "/v3",
+		"access_key":   "automation-ak",
+		"secret_key":   "automation-sk",
+		"project_id":   testIAMProjectID,
+		"domain_id":    testIAMDomainID,
+		"max_retries":  0,
+		"endpoints":    endpoints,
+		"check_quotas": checkQuotas,
+	}))
+	if diags.HasError() {
+		t.Fatalf("err: %v", diags)
+	}
+	return provider
+}
+
+func testQuotaWarnings(t *testing.T, provider *schema.Provider, name string, raw map[string]interface{}) []string {
+	return testPlannedQuotaWarnings(t, provider, name, raw, nil)
+}
+
+// testPlannedQuotaWarnings plans the resource after the planned resources, which it is added to.
+func testPlannedQuotaWarnings(t *testing.T, provider *schema.Provider, name string, raw map[string]interface{},
+	planned *plannedQuotas) []string {
+	ctx, warnings := withPlanWarnings(context.Background(), planned)
+	r := provider.ResourcesMap[name]
+	if _, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), provider.Meta()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	planned.add(warnings.requests())
+
+	var summaries []string
+	for _, d := range warnings.diagnostics() {
+		summaries = append(summaries, d.Summary)
+	}
+	return summaries
+}
+
+func TestWrapResourceQuotaCheck(t *testing.T) {
+	quotas := newTestQuotaServer(t)
+	provider := testQuotaProvider(t, quotas, true)
+
+	cases := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected []string
+	}{
+		{
+			name: "sbercloud_compute_instance",
+			raw: map[string]interface{}{
+				"name":              "ecs-1",
+				"image_id":          "image-1",
+				"flavor_id":         "s6.small.1",
+				"availability_zone": "ru-moscow-1a",
+				"network":           []interface{}{map[string]interface{}{"uuid": "subnet-1"}},
+			},
+			expected: []string{"The compute quota of instances would be exceeded"},
+		},
+		{
+			name: "sbercloud_compute_instance",
+			raw: map[string]interface{}{
+				"name":              "ecs-1",
+				"image_id":          "image-1",
+				"flavor_name":       "s6.xlarge.2",
+				"availability_zone": "ru-moscow-1a",
+				"network":           []interface{}{map[string]interface{}{"uuid": "subnet-1"}},
+			},
+			expected: []string{
+				"The compute quota of instances would be exceeded",
+				"The compute quota of cores would be exceeded",
+				"The compute quota of ram would be exceeded",
+			},
+		},
+		{
+			name: "sbercloud_evs_volume",
+			raw: map[string]interface{}{
+				"availability_zone": "ru-moscow-1a",
+				"volume_type":       "SSD",
+				"size":              200,
+			},
+			expected: []string{"The evs quota of gigabytes would be exceeded"},
+		},
+		{
+			name: "sbercloud_vpc",
+			raw: map[string]interface{}{
+				"name": "vpc-1",
+				"cidr": "192.168.0.0/16",
+			},
+		},
+		{
+			name: "sbercloud_vpc_eip",
+			raw: map[string]interface{}{
+				"publicip":  []interface{}{map[string]interface{}{"type": "5_bgp"}},
+				"bandwidth": []interface{}{map[string]interface{}{"share_type": "PER", "name": "bw-1", "size": 5}},
+			},
+		},
+	}
+	for _, c := range cases {
+		if got := testQuotaWarnings(t, provider, c.name, c.raw); !reflect.DeepEqual(got, c.expected) {
+			t.Fatalf("%s: expected the warnings %v, got %v", c.name, c.expected, got)
+		}
+	}
+
+	// the quotas are only checked with check_quotas
+	provider = testQuotaProvider(t, quotas, false)
+	requests := quotas.requestCount()
+	if got := testQuotaWarnings(t, provider, "sbercloud_vpc", map[string]interface{}{
+		"name": "vpc-1",
+		"cidr": "192.168.0.0/16",
+	}); len(got) != 0 || quotas.requestCount() != requests {
+		t.Fatalf("expected the quotas not to be queried without check_quotas")
+	}
+}
+
+func TestWrapResourceQuotaCheck_planned(t *testing.T) {
+	quotas := newTestQuotaServer(t)
+	provider := testQuotaProvider(t, quotas, true)
+	planned := newPlannedQuotas()
+
+	volume := map[string]interface{}{
+		"availability_zone": "ru-moscow-1a",
+		"volume_type":       "SSD",
+		"size":              60,
+	}
+	if got := testPlannedQuotaWarnings(t, provider, "sbercloud_evs_volume", volume, planned); len(got) != 0 {
+		t.Fatalf("expected no warnings for the first volume, got %v", got)
+	}
+
+	ctx, warnings := withPlanWarnings(context.Background(), planned)
+	r := provider.ResourcesMap["sbercloud_evs_volume"]
+	if _, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(volume), provider.Meta()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	diags := warnings.diagnostics()
+	if len(diags) != 1 || diags[0].Summary != "The evs quota of gigabytes would be exceeded" ||
+		!strings.Contains(diags[0].Detail, "where 900 of 1000 are used and 60 are needed by the other new resources") {
+		t.Fatalf("expected the second volume to exceed the gigabytes quota, got %v", diags)
+	}
+
+	// the CustomizeDiff of the same resource is not counted twice
+	if _, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(volume), provider.Meta()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	key := quotaKey{Region: "ru-moscow-1", Service: quotaServiceEVS, Type: "gigabytes"}
+	if amount := warnings.requests()[key]; amount != 60 {
+		t.Fatalf("expected the volume to need 60 gigabytes, got %d", amount)
+	}
+}
+
+func TestWrapResourceQuotaCheck_queriedOnce(t *testing.T) {
+	quotas := newTestQuotaServer(t)
+	provider := testQuotaProvider(t, quotas, true)
+	planned := newPlannedQuotas()
+
+	instance := map[string]interface{}{
+		"name":              "ecs-1",
+		"image_id":          "image-1",
+		"flavor_name":       "s6.xlarge.2",
+		"availability_zone": "ru-moscow-1a",
+		"network":           []interface{}{map[string]interface{}{"uuid": "subnet-1"}},
+	}
+	testPlannedQuotaWarnings(t, provider, "sbercloud_compute_instance", instance, planned)
+	requests := quotas.requestCount()
+
+	// the quotas and the flavor of the region are reused by the other resources of the plan
+	for i := 0; i < 3; i++ {
+		testPlannedQuotaWarnings(t, provider, "sbercloud_compute_instance", instance, planned)
+	}
+	if n := quotas.requestCount() - requests; n != 0 {
+		t.Fatalf("expected the quotas and the flavor to be queried once per plan, got %d more requests", n)
+	}
+}
+
+func TestProviderServer_checkQuotas(t *testing.T) {
+	quotas := newTestQuotaServer(t)
+	quotas.vpcUsed = 5
+
+	endpoints := make(map[string]cty.Value)
+	for k, v := range quotas.endpoints() {
+		endpoints[k] = cty.StringVal(v.(string))
+	}
+	iam := newTestIAMServer(t)
+	endpoints["iam"] = cty.StringVal(iam.URL)
+
+	configure := func(checkQuotas bool) (*schema.Provider, *providerServer) {
+		provider := Provider()
+		s := newProviderServer(provider)
+
+		ty := schema.InternalMap(provider.Schema).CoreConfigSchema().ImpliedType()
+		providerConfig := testResourceValue(ty, map[string]cty.Value{
+			"region":       cty.StringVal("ru-moscow-1"),
+			"auth_url":     cty.StringVal(iam.URL + "/v3"),
+			"access_key":   cty.StringVal("automation-ak"),
+			"secret_key":   cty.StringVal("automation-sk"),
+			"project_id":   cty.StringVal(testIAMProjectID),
+			"max_retries":  cty.NumberIntVal(0),
+			"endpoints":    cty.MapVal(endpoints),
+			"check_quotas": cty.BoolVal(checkQuotas),
+		})
+		configureResp, err := s.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{
+			TerraformVersion: "0.14.0",
+			Config:           mustEncodeDynamicValue(t, providerConfig, ty),
+		})
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		expectNoDiagnostics(t, configureResp.Diagnostics)
+		return provider, s
+	}
+	plan := func(provider *schema.Provider, s *providerServer) []*tfprotov5.Diagnostic {
+		vpcType := provider.ResourcesMap["sbercloud_vpc"].CoreConfigSchema().ImpliedType()
+		config := testResourceValue(vpcType, map[string]cty.Value{
+			"name": cty.StringVal("vpc-1"),
+			"cidr": cty.StringVal("192.168.0.0/16"),
+		})
+		resp, err := s.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "sbercloud_vpc",
+			PriorState:       mustEncodeDynamicValue(t, cty.NullVal(vpcType), vpcType),
+			ProposedNewState: mustEncodeDynamicValue(t, config, vpcType),
+			Config:           mustEncodeDynamicValue(t, config, vpcType),
+		})
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		expectNoDiagnostics(t, resp.Diagnostics)
+		return resp.Diagnostics
+	}
+	planVPC := func(checkQuotas bool) []*tfprotov5.Diagnostic {
+		return plan(configure(checkQuotas))
+	}
+
+	diags := planVPC(true)
+	if len(diags) != 1 || diags[0].Severity != tfprotov5.DiagnosticSeverityWarning ||
+		diags[0].Summary != "The network quota of vpc would be exceeded" ||
+		!strings.Contains(diags[0].Detail, "where 5 of 5 are used") {
+		t.Fatalf("expected a warning about the vpc quota, got %v", diags)
+	}
+
+	if diags := planVPC(false); len(diags) != 0 {
+		t.Fatalf("expected no warnings without check_quotas, got %v", diags)
+	}
+
+	// the VPCs planned by the provider before are added to the used ones
+	quotas.mu.Lock()
+	quotas.vpcUsed = 3
+	quotas.mu.Unlock()
+	provider, s := configure(true)
+	for i := 0; i < 2; i++ {
+		if diags := plan(provider, s); len(diags) != 0 {
+			t.Fatalf("expected no warnings for the VPC %d, got %v", i+1, diags)
+		}
+	}
+	diags = plan(provider, s)
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail, "where 3 of 5 are used and 2 are needed by the other new resources") {
+		t.Fatalf("expected the third VPC to exceed the vpc quota, got %v", diags)
+	}
+}
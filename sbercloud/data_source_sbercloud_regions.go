@@ -0,0 +1,99 @@
+package sbercloud
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
+)
+
+// systemProjects are the IAM projects which do not belong to a region.
+var systemProjects = map[string]bool{
+	"MOS": true,
+}
+
+func DataSourceRegions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRegionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"projects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRegionsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*config.Config)
+
+	all, err := listAuthProjects(config, config.Region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+
+	names := make([]string, 0, len(all))
+	projects := make([]map[string]interface{}, 0, len(all))
+	seen := make(map[string]bool)
+	for _, project := range all {
+		if systemProjects[project.Name] {
+			continue
+		}
+
+		// the subprojects of a region are named {region}_{name}
+		region := strings.SplitN(project.Name, "_", 2)[0]
+		if !seen[region] {
+			seen[region] = true
+			names = append(names, region)
+		}
+		projects = append(projects, map[string]interface{}{
+			"id":      project.ID,
+			"name":    project.Name,
+			"region":  region,
+			"enabled": project.Enabled,
+		})
+	}
+
+	d.SetId(hashcode.Strings(names))
+	mErr := multierror.Append(nil,
+		d.Set("names", names),
+		d.Set("projects", projects),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting regions attributes: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package sbercloud
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccRegions_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRegionsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.sbercloud_regions.all", "names.#", regexp.MustCompile("[1-9]\\d*")),
+					resource.TestCheckTypeSetElemAttr("data.sbercloud_regions.all", "names.*", SBC_REGION_NAME),
+				),
+			},
+		},
+	})
+}
+
+const testAccRegionsConfig_basic = `
+data "sbercloud_regions" "all" {}
+`
+
+func TestDataSourceRegions(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "automation-ak",
+		"secret_key":  "automation-sk",
+		"max_retries": 0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	}), "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, DataSourceRegions().Schema, map[string]interface{}{})
+	if diags := dataSourceRegionsRead(context.Background(), d, meta); len(diags) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	expected := []interface{}{"ru-moscow-1", "ru-moscow-2"}
+	if names := d.Get("names").([]interface{}); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected the regions %v, got %v", expected, names)
+	}
+
+	projects := d.Get("projects").([]interface{})
+	if len(projects) != 3 {
+		t.Fatalf("expected 3 projects without the system projects, got %v", projects)
+	}
+	sub := projects[1].(map[string]interface{})
+	if sub["id"] != testIAMSubProjectID || sub["name"] != "ru-moscow-1_dev" || sub["region"] != "ru-moscow-1" {
+		t.Fatalf("unexpected subproject: %v", sub)
+	}
+}
@@ -0,0 +1,332 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
+)
+
+// the measurement units of the amounts returned by BSS and their number in the main
+// unit of the currency: 1: rubles, 2: tenths of a ruble, 3: kopecks
+var priceMeasureUnits = map[int]float64{
+	1: 1,
+	2: 10,
+	3: 100,
+}
+
+// the measurement unit of the pay-per-use duration
+const priceUsageMeasureHour = 4
+
+func DataSourceResourcePrice() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceResourcePriceRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"cloud_service_type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"resource_spec": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"resource_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"size_measure_id"},
+			},
+			"size_measure_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"resource_size"},
+			},
+			"charging_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "postPaid",
+				ValidateFunc: validation.StringInSlice([]string{"prePaid", "postPaid"}, false),
+			},
+			"period_unit": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"hour", "month", "year"}, false),
+			},
+			"period": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"quantity": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"product_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"amount": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"official_amount": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"discount_amount": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"currency": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// priceProductInfo is the product whose price is inquired.
+type priceProductInfo struct {
+	ID               string `json:"id"`
+	CloudServiceType string `json:"cloud_service_type"`
+	ResourceType     string `json:"resource_type"`
+	ResourceSpec     string `json:"resource_spec"`
+	Region           string `json:"region"`
+	AvailableZone    string `json:"available_zone,omitempty"`
+	ResourceSize     int    `json:"resource_size,omitempty"`
+	SizeMeasureID    int    `json:"size_measure_id,omitempty"`
+	SubscriptionNum  int    `json:"subscription_num"`
+
+	// the pay-per-use duration
+	UsageFactor    string `json:"usage_factor,omitempty"`
+	UsageValue     int    `json:"usage_value,omitempty"`
+	UsageMeasureID int    `json:"usage_measure_id,omitempty"`
+
+	// the yearly/monthly period, 2: month, 3: year
+	PeriodType int `json:"period_type,omitempty"`
+	PeriodNum  int `json:"period_num,omitempty"`
+}
+
+type priceInquiryOpts struct {
+	ProjectID    string             `json:"project_id"`
+	ProductInfos []priceProductInfo `json:"product_infos"`
+}
+
+type productRatingResult struct {
+	ID                    string  `json:"id"`
+	ProductID             string  `json:"product_id"`
+	Amount                float64 `json:"amount"`
+	OfficialWebsiteAmount float64 `json:"official_website_amount"`
+	DiscountAmount        float64 `json:"discount_amount"`
+	MeasureID             int     `json:"measure_id"`
+}
+
+type onDemandRatingResult struct {
+	Amount                float64               `json:"amount"`
+	OfficialWebsiteAmount float64               `json:"official_website_amount"`
+	DiscountAmount        float64               `json:"discount_amount"`
+	MeasureID             int                   `json:"measure_id"`
+	Currency              string                `json:"currency"`
+	ProductRatingResults  []productRatingResult `json:"product_rating_results"`
+}
+
+type periodRatingResult struct {
+	OfficialWebsiteRatingResult struct {
+		OfficialWebsiteAmount float64               `json:"official_website_amount"`
+		MeasureID             int                   `json:"measure_id"`
+		ProductRatingResults  []productRatingResult `json:"product_rating_results"`
+	} `json:"official_website_rating_result"`
+	OptionalDiscountRatingResults []struct {
+		Amount                float64 `json:"amount"`
+		OfficialWebsiteAmount float64 `json:"official_website_amount"`
+		DiscountAmount        float64 `json:"discount_amount"`
+		MeasureID             int     `json:"measure_id"`
+	} `json:"optional_discount_rating_results"`
+	Currency string `json:"currency"`
+}
+
+// resourcePrice is the price of the product in the main unit of the currency.
+type resourcePrice struct {
+	productID      string
+	amount         float64
+	officialAmount float64
+	discountAmount float64
+	currency       string
+}
+
+func dataSourceResourcePriceRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+
+	chargingMode := d.Get("charging_mode").(string)
+	periodUnit := d.Get("period_unit").(string)
+	switch {
+	case chargingMode == chargingModePrePaid && periodUnit != "month" && periodUnit != "year":
+		return diag.Errorf("period_unit must be month or year in the prePaid charging mode")
+	case chargingMode != chargingModePrePaid && periodUnit == "":
+		periodUnit = "hour"
+	case chargingMode != chargingModePrePaid && periodUnit != "hour":
+		return diag.Errorf("period_unit must be hour in the postPaid charging mode")
+	}
+
+	bssV2Client, err := config.BssV2Client(region)
+	if err != nil {
+		return diag.Errorf("Error creating SberCloud bss V2 client: %s", err)
+	}
+
+	product := priceProductInfo{
+		ID:               "1",
+		CloudServiceType: d.Get("cloud_service_type").(string),
+		ResourceType:     d.Get("resource_type").(string),
+		ResourceSpec:     d.Get("resource_spec").(string),
+		Region:           region,
+		AvailableZone:    d.Get("availability_zone").(string),
+		ResourceSize:     d.Get("resource_size").(int),
+		SizeMeasureID:    d.Get("size_measure_id").(int),
+		SubscriptionNum:  d.Get("quantity").(int),
+	}
+
+	var price *resourcePrice
+	if chargingMode == chargingModePrePaid {
+		product.PeriodType = 2
+		if periodUnit == "year" {
+			product.PeriodType = 3
+		}
+		product.PeriodNum = d.Get("period").(int)
+		price, err = inquirePeriodPrice(bssV2Client, product)
+	} else {
+		product.UsageFactor = "Duration"
+		product.UsageValue = d.Get("period").(int)
+		product.UsageMeasureID = priceUsageMeasureHour
+		price, err = inquireOnDemandPrice(bssV2Client, product)
+	}
+	if err != nil {
+		return diag.Errorf("Error inquiring the price of %s: %s", product.ResourceSpec, err)
+	}
+
+	d.SetId(hashcode.Strings([]string{region, product.CloudServiceType, product.ResourceType, product.ResourceSpec,
+		product.AvailableZone, fmt.Sprint(product.ResourceSize), chargingMode, periodUnit,
+		fmt.Sprint(d.Get("period").(int)), fmt.Sprint(product.SubscriptionNum)}))
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("period_unit", periodUnit),
+		d.Set("product_id", price.productID),
+		d.Set("amount", price.amount),
+		d.Set("official_amount", price.officialAmount),
+		d.Set("discount_amount", price.discountAmount),
+		d.Set("currency", price.currency),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting resource price attributes: %s", err)
+	}
+
+	return nil
+}
+
+func inquireOnDemandPrice(client *golangsdk.ServiceClient, product priceProductInfo) (*resourcePrice, error) {
+	opts := priceInquiryOpts{
+		ProjectID:    client.ProjectID,
+		ProductInfos: []priceProductInfo{product},
+	}
+	log.Printf("[DEBUG] Inquiry Options of the pay-per-use price: %#v", opts)
+
+	var result onDemandRatingResult
+	_, err := client.Post(client.ServiceURL("bills", "ratings", "on-demand-resources"), opts, &result,
+		&golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	unit, err := priceMeasureUnit(result.MeasureID)
+	if err != nil {
+		return nil, err
+	}
+	price := &resourcePrice{
+		amount:         result.Amount / unit,
+		officialAmount: result.OfficialWebsiteAmount / unit,
+		discountAmount: result.DiscountAmount / unit,
+		currency:       result.Currency,
+	}
+	if len(result.ProductRatingResults) > 0 {
+		price.productID = result.ProductRatingResults[0].ProductID
+	}
+	return price, nil
+}
+
+// inquirePeriodPrice returns the yearly/monthly price with the best of the optional discounts.
+func inquirePeriodPrice(client *golangsdk.ServiceClient, product priceProductInfo) (*resourcePrice, error) {
+	opts := priceInquiryOpts{
+		ProjectID:    client.ProjectID,
+		ProductInfos: []priceProductInfo{product},
+	}
+	log.Printf("[DEBUG] Inquiry Options of the yearly/monthly price: %#v", opts)
+
+	var result periodRatingResult
+	_, err := client.Post(client.ServiceURL("bills", "ratings", "period-resources", "subscribe-rate"), opts,
+		&result, &golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	official := result.OfficialWebsiteRatingResult
+	unit, err := priceMeasureUnit(official.MeasureID)
+	if err != nil {
+		return nil, err
+	}
+	price := &resourcePrice{
+		amount:         official.OfficialWebsiteAmount / unit,
+		officialAmount: official.OfficialWebsiteAmount / unit,
+		currency:       result.Currency,
+	}
+	if len(official.ProductRatingResults) > 0 {
+		price.productID = official.ProductRatingResults[0].ProductID
+	}
+
+	for _, discount := range result.OptionalDiscountRatingResults {
+		unit, err := priceMeasureUnit(discount.MeasureID)
+		if err != nil {
+			return nil, err
+		}
+		if amount := discount.Amount / unit; amount < price.amount {
+			price.amount = amount
+			price.discountAmount = discount.DiscountAmount / unit
+		}
+	}
+	return price, nil
+}
+
+func priceMeasureUnit(measureID int) (float64, error) {
+	unit, ok := priceMeasureUnits[measureID]
+	if !ok {
+		return 0, fmt.Errorf("unsupported measurement unit of the amount: %d", measureID)
+	}
+	return unit, nil
+}
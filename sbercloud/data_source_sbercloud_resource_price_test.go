@@ -0,0 +1,256 @@
+package sbercloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccResourcePrice_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePriceConfig_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sbercloud_resource_price.ecs", "period_unit", "hour"),
+					resource.TestMatchResourceAttr("data.sbercloud_resource_price.ecs", "official_amount",
+						regexp.MustCompile(`^[0-9.]+$`)),
+					resource.TestCheckResourceAttrSet("data.sbercloud_resource_price.ecs", "currency"),
+					resource.TestMatchResourceAttr("data.sbercloud_resource_price.evs", "official_amount",
+						regexp.MustCompile(`^[0-9.]+$`)),
+					resource.TestCheckResourceAttrSet("data.sbercloud_resource_price.evs", "currency"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourcePriceConfig_basic() string {
+	return fmt.Sprintf(`
+data "sbercloud_availability_zones" "test" {}
+
+data "sbercloud_resource_price" "ecs" {
+  cloud_service_type = "hws.service.type.ec2"
+  resource_type      = "hws.resource.type.vm"
+  resource_spec      = "s6.small.1.linux"
+  region             = "%[1]s"
+  availability_zone  = data.sbercloud_availability_zones.test.names[0]
+}
+
+data "sbercloud_resource_price" "evs" {
+  cloud_service_type = "hws.service.type.ebs"
+  resource_type      = "hws.resource.type.volume"
+  resource_spec      = "SSD"
+  region             = "%[1]s"
+  availability_zone  = data.sbercloud_availability_zones.test.names[0]
+  resource_size      = 100
+  size_measure_id    = 17
+  charging_mode      = "prePaid"
+  period_unit        = "month"
+  period             = 1
+}
+`, SBC_REGION_NAME)
+}
+
+// synthetic responses in the format of the BSS price inquiry API, the IDs and amounts
+// are made up, and the amounts of the yearly/monthly price are returned in kopecks
+const (
+	testOnDemandPriceResponse = `{
+  "amount": 2.9,
+  "discount_amount": 0,
+  "official_website_amount": 2.9,
+  "measure_id": 1,
+  "currency": "RUB",
+  "product_rating_results": [
+    {
+      "id": "1",
+      "product_id": "OFFI778311684493803526",
+      "amount": 2.9,
+      "discount_amount": 0,
+      "official_website_amount": 2.9,
+      "measure_id": 1
+    }
+  ]
+}`
+	testPeriodPriceResponse = `{
+  "official_website_rating_result": {
+    "official_website_amount": 120000,
+    "measure_id": 3,
+    "product_rating_results": [
+      {
+        "id": "1",
+        "product_id": "OFFI778311696527425538",
+        "official_website_amount": 120000,
+        "measure_id": 3
+      }
+    ]
+  },
+  "optional_discount_rating_results": [
+    {
+      "discount_id": "5d3b1b1a-9e2e-4c15-8d5e-2b8c1d9e7f21",
+      "amount": 108000,
+      "official_website_amount": 120000,
+      "discount_amount": 12000,
+      "measure_id": 3,
+      "discount_type": 605
+    },
+    {
+      "discount_id": "8c4f7a6e-1b3d-4e2f-9a5c-7d6e8f9a0b12",
+      "amount": 114000,
+      "official_website_amount": 120000,
+      "discount_amount": 6000,
+      "measure_id": 3,
+      "discount_type": 610
+    }
+  ],
+  "currency": "RUB"
+}`
+)
+
+// newTestPriceServer is a local stand-in for the BSS price inquiry API which returns the
+// synthetic responses and stores the inquired products.
+func newTestPriceServer(t *testing.T) (*httptest.Server, func() map[string]interface{}) {
+	var mu sync.Mutex
+	var inquiry map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		inquiry = nil
+		_ = json.NewDecoder(r.Body).Decode(&inquiry)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/bills/ratings/on-demand-resources":
+			_, _ = w.Write([]byte(testOnDemandPriceResponse))
+		case "/v2/bills/ratings/period-resources/subscribe-rate":
+			_, _ = w.Write([]byte(testPeriodPriceResponse))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() map[string]interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		return inquiry
+	}
+}
+
+func testReadResourcePrice(t *testing.T, meta interface{}, raw map[string]interface{}) *schema.ResourceData {
+	d := schema.TestResourceDataRaw(t, DataSourceResourcePrice().Schema, raw)
+	if diags := dataSourceResourcePriceRead(context.Background(), d, meta); len(diags) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return d
+}
+
+func TestDataSourceResourcePrice_postPaid(t *testing.T) {
+	bss, inquiry := newTestPriceServer(t)
+	meta := testProviderMeta(t, map[string]interface{}{"bssv2": bss.URL + "/"})
+
+	d := testReadResourcePrice(t, meta, map[string]interface{}{
+		"cloud_service_type": "hws.service.type.ec2",
+		"resource_type":      "hws.resource.type.vm",
+		"resource_spec":      "s6.small.1.linux",
+		"availability_zone":  "ru-moscow-1a",
+		"period":             2,
+		"quantity":           3,
+	})
+
+	expected := map[string]interface{}{
+		"project_id": testIAMProjectID,
+		"product_infos": []interface{}{
+			map[string]interface{}{
+				"id":                 "1",
+				"cloud_service_type": "hws.service.type.ec2",
+				"resource_type":      "hws.resource.type.vm",
+				"resource_spec":      "s6.small.1.linux",
+				"region":             "ru-moscow-1",
+				"available_zone":     "ru-moscow-1a",
+				"subscription_num":   3.0,
+				"usage_factor":       "Duration",
+				"usage_value":        2.0,
+				"usage_measure_id":   4.0,
+			},
+		},
+	}
+	if got := inquiry(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("unexpected inquiry:\n%v\nexpected:\n%v", got, expected)
+	}
+
+	if d.Get("period_unit") != "hour" || d.Get("product_id") != "OFFI778311684493803526" ||
+		d.Get("amount") != 2.9 || d.Get("official_amount") != 2.9 || d.Get("discount_amount") != 0.0 ||
+		d.Get("currency") != "RUB" {
+		t.Fatalf("unexpected price: %v", d.State().Attributes)
+	}
+}
+
+func TestDataSourceResourcePrice_prePaid(t *testing.T) {
+	bss, inquiry := newTestPriceServer(t)
+	meta := testProviderMeta(t, map[string]interface{}{"bssv2": bss.URL + "/"})
+
+	d := testReadResourcePrice(t, meta, map[string]interface{}{
+		"cloud_service_type": "hws.service.type.ebs",
+		"resource_type":      "hws.resource.type.volume",
+		"resource_spec":      "SSD",
+		"resource_size":      100,
+		"size_measure_id":    17,
+		"charging_mode":      "prePaid",
+		"period_unit":        "year",
+		"period":             1,
+	})
+
+	product := inquiry()["product_infos"].([]interface{})[0].(map[string]interface{})
+	if product["period_type"] != 3.0 || product["period_num"] != 1.0 || product["resource_size"] != 100.0 ||
+		product["size_measure_id"] != 17.0 || product["usage_factor"] != nil {
+		t.Fatalf("unexpected product: %v", product)
+	}
+
+	// the best discount is chosen and the kopecks are converted to roubles
+	if d.Get("product_id") != "OFFI778311696527425538" || d.Get("amount") != 1080.0 ||
+		d.Get("official_amount") != 1200.0 || d.Get("discount_amount") != 120.0 || d.Get("currency") != "RUB" {
+		t.Fatalf("unexpected price: %v", d.State().Attributes)
+	}
+}
+
+func TestDataSourceResourcePrice_periodUnit(t *testing.T) {
+	bss, _ := newTestPriceServer(t)
+	meta := testProviderMeta(t, map[string]interface{}{"bssv2": bss.URL + "/"})
+
+	cases := []struct {
+		chargingMode string
+		periodUnit   string
+		err          string
+	}{
+		{"prePaid", "", "period_unit must be month or year in the prePaid charging mode"},
+		{"prePaid", "hour", "period_unit must be month or year in the prePaid charging mode"},
+		{"postPaid", "month", "period_unit must be hour in the postPaid charging mode"},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, DataSourceResourcePrice().Schema, map[string]interface{}{
+			"cloud_service_type": "hws.service.type.ec2",
+			"resource_type":      "hws.resource.type.vm",
+			"resource_spec":      "s6.small.1.linux",
+			"charging_mode":      c.chargingMode,
+			"period_unit":        c.periodUnit,
+		})
+		diags := dataSourceResourcePriceRead(context.Background(), d, meta)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, c.err) {
+			t.Fatalf("%s/%s: expected %q, got %v", c.chargingMode, c.periodUnit, c.err, diags)
+		}
+	}
+}
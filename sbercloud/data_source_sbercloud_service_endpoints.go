@@ -0,0 +1,72 @@
+package sbercloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
+)
+
+func DataSourceServiceEndpoints() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceServiceEndpointsRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"services": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(allServiceCatalogKeys, false),
+				},
+			},
+			"endpoints": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceServiceEndpointsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*config.Config)
+	region := config.GetRegion(d)
+
+	services := allServiceCatalogKeys
+	if v, ok := d.GetOk("services"); ok {
+		services = make([]string, 0, v.(*schema.Set).Len())
+		for _, service := range v.(*schema.Set).List() {
+			services = append(services, service.(string))
+		}
+	}
+
+	endpoints := make(map[string]interface{}, len(services))
+	for _, service := range services {
+		endpoint, err := serviceEndpoint(config, service, region)
+		if err != nil {
+			return diag.Errorf("error resolving the endpoint of %s in %s: %s", service, region, err)
+		}
+		endpoints[service] = endpoint
+	}
+
+	d.SetId(hashcode.Strings(append([]string{region}, services...)))
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("endpoints", endpoints),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting service endpoints attributes: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package sbercloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccServiceEndpoints_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceEndpointsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.sbercloud_service_endpoints.test", "endpoints.%", "2"),
+					resource.TestCheckResourceAttrSet("data.sbercloud_service_endpoints.test", "endpoints.ecs"),
+					resource.TestCheckResourceAttrSet("data.sbercloud_service_endpoints.test", "endpoints.vpc"),
+				),
+			},
+		},
+	})
+}
+
+const testAccServiceEndpointsConfig_basic = `
+data "sbercloud_service_endpoints" "test" {
+  services = ["ecs", "vpc"]
+}
+`
+
+func TestDataSourceServiceEndpoints(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "automation-ak",
+		"secret_key":  "automation-sk",
+		"cloud":       "example.com",
+		"max_retries": 0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+			"ecs": "ecs.internal.example.com",
+		},
+	}), "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	cases := []struct {
+		region   string
+		expected map[string]string
+	}{
+		{"", map[string]string{
+			"ecs":    "https://ecs.internal.example.com/",
+			"ecsv21": "https://ecs.internal.example.com/",
+			"vpc":    "https://vpc.ru-moscow-1.example.com/",
+			"rds":    "https://rds.ru-moscow-1.example.com/",
+			"iam":    iam.URL + "/",
+			"obs":    "https://obs.ru-moscow-1.example.com/",
+			"cbr":    "https://cbr.ru-moscow-1.example.com/",
+		}},
+		{"ru-moscow-2", map[string]string{
+			"vpc": "https://vpc.ru-moscow-2.example.com/",
+			"obs": "https://obs.ru-moscow-2.example.com/",
+		}},
+	}
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, DataSourceServiceEndpoints().Schema, map[string]interface{}{
+			"region": tc.region,
+		})
+		if diags := dataSourceServiceEndpointsRead(context.Background(), d, meta); len(diags) > 0 {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		endpoints := d.Get("endpoints").(map[string]interface{})
+		if len(endpoints) != len(allServiceCatalogKeys) {
+			t.Fatalf("expected the endpoints of all services, got %d", len(endpoints))
+		}
+		for service, endpoint := range tc.expected {
+			if endpoints[service] != endpoint {
+				t.Fatalf("expected the endpoint %s of %s in %q, got %v", endpoint, service, tc.region, endpoints[service])
+			}
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, DataSourceServiceEndpoints().Schema, map[string]interface{}{
+		"services": []interface{}{"vpc"},
+	})
+	if diags := dataSourceServiceEndpointsRead(context.Background(), d, meta); len(diags) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if endpoints := d.Get("endpoints").(map[string]interface{}); len(endpoints) != 1 {
+		t.Fatalf("expected the endpoint of vpc only, got %v", endpoints)
+	}
+}
@@ -0,0 +1,164 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// swrImageTag is an image tag of a SWR repository, the tags are not queried by the swr package of golangsdk.
+type swrImageTag struct {
+	Tag          string `json:"Tag"`
+	ImageID      string `json:"image_id"`
+	Digest       string `json:"digest"`
+	Size         int    `json:"size"`
+	Path         string `json:"path"`
+	InternalPath string `json:"internal_path"`
+	Created      string `json:"created"`
+	Updated      string `json:"updated"`
+}
+
+func DataSourceSWRImageTags() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSWRImageTagsRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"organization": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"repository": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"digest": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"image_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"internal_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"digest_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// listSWRImageTags returns the image tags of a repository, the most recently updated first.
+func listSWRImageTags(client *golangsdk.ServiceClient, organization, repository string) ([]swrImageTag, error) {
+	all := make([]swrImageTag, 0)
+	for offset := 0; ; offset += swrPageLimit {
+		url := fmt.Sprintf("%s?offset=%d&limit=%d&order_column=updated_time&order_type=desc",
+			client.ServiceURL("manage", "namespaces", organization, "repos", repository, "tags"), offset, swrPageLimit)
+		var page []swrImageTag
+		_, err := client.Get(url, &page, &golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, page...)
+		if len(page) < swrPageLimit {
+			return all, nil
+		}
+	}
+}
+
+func dataSourceSWRImageTagsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	client, err := config.SwrV2Client(region)
+	if err != nil {
+		return diag.Errorf("Error creating SberCloud SWR client: %s", err)
+	}
+
+	organization := d.Get("organization").(string)
+	repository := d.Get("repository").(string)
+	all, err := listSWRImageTags(client, organization, repository)
+	if err != nil {
+		return diag.Errorf("Error querying the image tags of the SWR repository (%s/%s): %s", organization, repository, err)
+	}
+
+	name := d.Get("name").(string)
+	result := make([]map[string]interface{}, 0, len(all))
+	for _, tag := range all {
+		if name != "" && tag.Tag != name {
+			continue
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":          tag.Tag,
+			"digest":        tag.Digest,
+			"image_id":      tag.ImageID,
+			"size":          tag.Size,
+			"path":          tag.Path,
+			"internal_path": tag.InternalPath,
+			// the address pins the image to the digest instead of the mutable tag
+			"digest_path": strings.TrimSuffix(tag.Path, ":"+tag.Tag) + "@" + tag.Digest,
+			"created_at":  tag.Created,
+			"updated_at":  tag.Updated,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", organization, repository))
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("tags", result),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting SWR image tags attributes: %s", err)
+	}
+
+	return nil
+}
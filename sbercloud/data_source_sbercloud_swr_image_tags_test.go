@@ -0,0 +1,106 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccSWRImageTagsDataSource_basic(t *testing.T) {
+	dataSourceName := "data.sbercloud_swr_image_tags.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckSWRRepository(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSWRImageTagsDataSource_basic(SBC_SWR_REPOSITORY),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "tags.0.name"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "tags.0.digest"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "tags.0.path"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "tags.0.digest_path"),
+				),
+			},
+		},
+	})
+}
+
+// testAccSWRImageTagsDataSource_basic queries the tags of a repository given as organization/repository.
+func testAccSWRImageTagsDataSource_basic(repository string) string {
+	parts := strings.SplitN(repository, "/", 2)
+	return fmt.Sprintf(`
+data "sbercloud_swr_image_tags" "test" {
+  organization = "%s"
+  repository   = "%s"
+}
+`, parts[0], parts[len(parts)-1])
+}
+
+func TestDataSourceSWRImageTags(t *testing.T) {
+	swr := newTestSWRServer(t)
+	meta := testProviderMeta(t, swr.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceSWRImageTags().Schema, map[string]interface{}{
+		"organization": "web",
+		"repository":   "nginx",
+	})
+	expectNoWarnings(t, dataSourceSWRImageTagsRead(context.Background(), d, meta))
+
+	if d.Id() != "web/nginx" {
+		t.Fatalf("unexpected ID: %s", d.Id())
+	}
+	if n := d.Get("tags.#").(int); n != 2 {
+		t.Fatalf("expected 2 tags, got %d", n)
+	}
+	tag := d.Get("tags.0").(map[string]interface{})
+	if tag["name"] != "latest" || tag["image_id"] != "image-latest" || tag["size"] != 512 {
+		t.Fatalf("unexpected tag: %v", tag)
+	}
+	expected := "swr.ru-moscow-1.hc.sbercloud.ru/web/nginx@sha256:0f4a2ac1b59e6a8b5bf5dd1bb1dc2dd5fa6d6e5f6a7b8c9d0e1f2a3b4c5d6e7f"
+	if tag["digest_path"] != expected {
+		t.Fatalf("expected the digest path %s, got %s", expected, tag["digest_path"])
+	}
+
+	swr.mu.Lock()
+	defer swr.mu.Unlock()
+	if len(swr.queries) != 1 || !strings.Contains(swr.queries[0], "order_column=updated_time&order_type=desc") {
+		t.Fatalf("expected the tags to be sorted by the update time, got %v", swr.queries)
+	}
+}
+
+func TestDataSourceSWRImageTags_name(t *testing.T) {
+	swr := newTestSWRServer(t)
+	meta := testProviderMeta(t, swr.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceSWRImageTags().Schema, map[string]interface{}{
+		"organization": "web",
+		"repository":   "nginx",
+		"name":         "1.21",
+	})
+	expectNoWarnings(t, dataSourceSWRImageTagsRead(context.Background(), d, meta))
+
+	if n := d.Get("tags.#").(int); n != 1 || d.Get("tags.0.image_id") != "image-121" {
+		t.Fatalf("expected only the 1.21 tag, got %v", d.Get("tags"))
+	}
+}
+
+func TestDataSourceSWRImageTags_notFound(t *testing.T) {
+	swr := newTestSWRServer(t)
+	meta := testProviderMeta(t, swr.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceSWRImageTags().Schema, map[string]interface{}{
+		"organization": "web",
+		"repository":   "missing",
+	})
+	if diags := dataSourceSWRImageTagsRead(context.Background(), d, meta); !diags.HasError() {
+		t.Fatalf("expected the missing repository to be reported")
+	}
+}
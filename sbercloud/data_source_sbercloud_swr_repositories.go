@@ -0,0 +1,204 @@
+package sbercloud
+
+import (
+	"context"
+	"strings"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/swr/v2/repositories"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
+)
+
+// the repositories and the image tags of SWR are listed by pages of this size
+var swrPageLimit = 100
+
+// swrRepository is a repository in the list of the repositories of SWR.
+type swrRepository struct {
+	repositories.ImageRepository
+	Tags []string `json:"tags"`
+}
+
+func DataSourceSWRRepositories() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSWRRepositoriesRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"category": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"repositories": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"organization": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"category": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_public": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"internal_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"num_images": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// listSWRRepositories returns all of the repositories matching the options, the SWR API returns the repositories
+// as a bare array which can not be paged by repositories.List.
+func listSWRRepositories(client *golangsdk.ServiceClient, opts repositories.ListOpts) ([]swrRepository, error) {
+	all := make([]swrRepository, 0)
+	for offset := 0; ; offset += swrPageLimit {
+		opts.Offset = &offset
+		opts.Limit = swrPageLimit
+		query, err := opts.ToRepositoryListQuery()
+		if err != nil {
+			return nil, err
+		}
+
+		var page []swrRepository
+		_, err = client.Get(client.ServiceURL("manage", "repos")+query, &page, &golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, page...)
+		if len(page) < swrPageLimit {
+			return all, nil
+		}
+	}
+}
+
+// swrPathOrganization returns the organization of a repository address such as
+// swr.ru-moscow-1.hc.sbercloud.ru/organization/repository.
+func swrPathOrganization(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[len(parts)-2]
+}
+
+func dataSourceSWRRepositoriesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*config.Config)
+	region := GetRegion(d, config)
+	client, err := config.SwrV2Client(region)
+	if err != nil {
+		return diag.Errorf("Error creating SberCloud SWR client: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	listOpts := repositories.ListOpts{
+		Namespace: d.Get("organization").(string),
+		Name:      name,
+		Category:  d.Get("category").(string),
+	}
+	all, err := listSWRRepositories(client, listOpts)
+	if err != nil {
+		return diag.Errorf("Error querying the SWR repositories: %s", err)
+	}
+
+	ids := make([]string, 0, len(all))
+	result := make([]map[string]interface{}, 0, len(all))
+	for _, repo := range all {
+		// the name is matched fuzzily by the API
+		if name != "" && repo.Name != name {
+			continue
+		}
+
+		ids = append(ids, repo.Path)
+		result = append(result, map[string]interface{}{
+			"id":            repo.ID,
+			"name":          repo.Name,
+			"organization":  swrPathOrganization(repo.Path),
+			"category":      repo.Category,
+			"description":   repo.Description,
+			"is_public":     repo.IsPublic,
+			"path":          repo.Path,
+			"internal_path": repo.InternalPath,
+			"num_images":    repo.NumImages,
+			"size":          repo.Size,
+			"tags":          repo.Tags,
+			"created_at":    repo.Created,
+			"updated_at":    repo.Updated,
+		})
+	}
+
+	d.SetId(hashcode.Strings(append([]string{region}, ids...)))
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("repositories", result),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return diag.Errorf("error setting SWR repositories attributes: %s", err)
+	}
+
+	return nil
+}
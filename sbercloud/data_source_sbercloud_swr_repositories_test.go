@@ -0,0 +1,223 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccSWRRepositoriesDataSource_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	dataSourceName := "data.sbercloud_swr_repositories.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSWRRepositoriesDataSource_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "repositories.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "repositories.0.name", rName),
+					resource.TestCheckResourceAttr(dataSourceName, "repositories.0.organization", rName),
+					resource.TestCheckResourceAttr(dataSourceName, "repositories.0.category", "linux"),
+					resource.TestCheckResourceAttr(dataSourceName, "repositories.0.is_public", "false"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "repositories.0.path"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSWRRepositoriesDataSource_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_swr_organization" "test" {
+  name = "%[1]s"
+}
+
+resource "sbercloud_swr_repository" "test" {
+  organization = sbercloud_swr_organization.test.name
+  name         = "%[1]s"
+  description  = "Test repository"
+  category     = "linux"
+  is_public    = false
+}
+
+data "sbercloud_swr_repositories" "test" {
+  organization = sbercloud_swr_repository.test.organization
+  name         = sbercloud_swr_repository.test.name
+}
+`, rName)
+}
+
+// testSWRServer is a local stand-in for the repository and image tag queries of SWR.
+type testSWRServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	repos   []map[string]interface{}
+	tags    map[string][]map[string]interface{}
+	queries []string
+}
+
+func newTestSWRServer(t *testing.T) *testSWRServer {
+	s := &testSWRServer{
+		repos: []map[string]interface{}{
+			{
+				"id": 1, "name": "nginx", "category": "app_server", "description": "the web server",
+				"is_public": false, "num_images": 2, "size": 1024,
+				"path":          "swr.ru-moscow-1.hc.sbercloud.ru/web/nginx",
+				"internal_path": "swr.ru-moscow-1.hc.sbercloud.ru/web/nginx",
+				"tags":          []string{"1.21", "latest"},
+				"created":       "2021-11-01T10:00:00Z",
+				"updated":       "2021-11-02T10:00:00Z",
+			},
+			{
+				"id": 2, "name": "nginx-exporter", "category": "other", "is_public": true, "num_images": 1,
+				"path": "swr.ru-moscow-1.hc.sbercloud.ru/web/nginx-exporter",
+			},
+			{
+				"id": 3, "name": "postgres", "category": "database", "num_images": 1,
+				"path": "swr.ru-moscow-1.hc.sbercloud.ru/db/postgres",
+			},
+		},
+		tags: map[string][]map[string]interface{}{
+			"web/nginx": {
+				{
+					"Tag": "latest", "image_id": "image-latest", "size": 512,
+					"digest":        "sha256:0f4a2ac1b59e6a8b5bf5dd1bb1dc2dd5fa6d6e5f6a7b8c9d0e1f2a3b4c5d6e7f",
+					"path":          "swr.ru-moscow-1.hc.sbercloud.ru/web/nginx:latest",
+					"internal_path": "swr.ru-moscow-1.hc.sbercloud.ru/web/nginx:latest",
+					"created":       "2021-11-02T10:00:00Z",
+					"updated":       "2021-11-02T10:00:00Z",
+				},
+				{
+					"Tag": "1.21", "image_id": "image-121", "size": 512,
+					"digest":        "sha256:7d1b2f5e0c3a4b6d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d",
+					"path":          "swr.ru-moscow-1.hc.sbercloud.ru/web/nginx:1.21",
+					"internal_path": "swr.ru-moscow-1.hc.sbercloud.ru/web/nginx:1.21",
+					"created":       "2021-11-01T10:00:00Z",
+					"updated":       "2021-11-01T10:00:00Z",
+				},
+			},
+		},
+	}
+
+	prefix := "/v2/manage/"
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+		parts := strings.Split(path, "/")
+		query := r.URL.Query()
+		switch {
+		case r.Method == http.MethodGet && path == "repos":
+			s.queries = append(s.queries, r.URL.RawQuery)
+			result := make([]map[string]interface{}, 0)
+			for _, repo := range s.repos {
+				namespace := strings.Split(repo["path"].(string), "/")[1]
+				if (query.Get("namespace") == "" || query.Get("namespace") == namespace) &&
+					strings.Contains(repo["name"].(string), query.Get("name")) {
+					result = append(result, repo)
+				}
+			}
+			writeTestJSON(w, testSWRPage(result, query.Get("offset"), query.Get("limit")))
+		case r.Method == http.MethodGet && len(parts) == 5 && parts[0] == "namespaces" && parts[4] == "tags":
+			s.queries = append(s.queries, r.URL.RawQuery)
+			tags, ok := s.tags[parts[1]+"/"+parts[3]]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeTestJSON(w, testSWRPage(tags, query.Get("offset"), query.Get("limit")))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// testSWRPage returns a page of a list, SWR returns the lists as bare arrays.
+func testSWRPage(items []map[string]interface{}, offset, limit string) []map[string]interface{} {
+	start, _ := strconv.Atoi(offset)
+	size, _ := strconv.Atoi(limit)
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+func (s *testSWRServer) endpoints() map[string]interface{} {
+	return map[string]interface{}{
+		"swr": s.URL + "/",
+	}
+}
+
+func TestDataSourceSWRRepositories(t *testing.T) {
+	swr := newTestSWRServer(t)
+	meta := testProviderMeta(t, swr.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceSWRRepositories().Schema, map[string]interface{}{})
+	expectNoWarnings(t, dataSourceSWRRepositoriesRead(context.Background(), d, meta))
+
+	if n := d.Get("repositories.#").(int); n != 3 {
+		t.Fatalf("expected 3 repositories, got %d", n)
+	}
+	repo := d.Get("repositories.0").(map[string]interface{})
+	if repo["name"] != "nginx" || repo["organization"] != "web" || repo["category"] != "app_server" {
+		t.Fatalf("unexpected repository: %v", repo)
+	}
+	if repo["num_images"] != 2 || repo["size"] != 1024 || repo["created_at"] != "2021-11-01T10:00:00Z" {
+		t.Fatalf("unexpected repository details: %v", repo)
+	}
+	if tags := repo["tags"].([]interface{}); len(tags) != 2 || tags[1] != "latest" {
+		t.Fatalf("unexpected tags: %v", tags)
+	}
+	if d.Get("repositories.2.organization") != "db" {
+		t.Fatalf("unexpected organization: %v", d.Get("repositories.2"))
+	}
+}
+
+func TestDataSourceSWRRepositories_filter(t *testing.T) {
+	pageLimit := swrPageLimit
+	swrPageLimit = 1
+	defer func() { swrPageLimit = pageLimit }()
+
+	swr := newTestSWRServer(t)
+	meta := testProviderMeta(t, swr.endpoints())
+
+	d := schema.TestResourceDataRaw(t, DataSourceSWRRepositories().Schema, map[string]interface{}{
+		"organization": "web",
+		"name":         "nginx",
+	})
+	expectNoWarnings(t, dataSourceSWRRepositoriesRead(context.Background(), d, meta))
+
+	// nginx-exporter is matched by the API, but not by the data source
+	if n := d.Get("repositories.#").(int); n != 1 || d.Get("repositories.0.name") != "nginx" {
+		t.Fatalf("expected only the nginx repository, got %v", d.Get("repositories"))
+	}
+
+	swr.mu.Lock()
+	defer swr.mu.Unlock()
+	if len(swr.queries) != 3 {
+		t.Fatalf("expected the repositories to be listed by 3 pages, got %v", swr.queries)
+	}
+	if swr.queries[0] != "limit=1&name=nginx&namespace=web&offset=0" {
+		t.Fatalf("unexpected query: %s", swr.queries[0])
+	}
+}
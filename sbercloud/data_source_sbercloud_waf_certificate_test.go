@@ -0,0 +1,43 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccWafCertificateDataSource_basic(t *testing.T) {
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	dataSourceName := "data.sbercloud_waf_certificate.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafCertificateDataSource_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id",
+						"sbercloud_waf_certificate.certificate_1", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "name", name),
+					resource.TestCheckResourceAttrSet(dataSourceName, "expiration"),
+				),
+			},
+		},
+	})
+}
+
+func testAccWafCertificateDataSource_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_waf_certificate" "test" {
+  name = sbercloud_waf_certificate.certificate_1.name
+}
+`, testAccWafCertificate_conf(name))
+}
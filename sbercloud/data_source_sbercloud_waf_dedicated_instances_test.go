@@ -0,0 +1,46 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccWafDedicatedInstancesDataSource_basic(t *testing.T) {
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	dataSourceName := "data.sbercloud_waf_dedicated_instances.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafDedicatedInstancesDataSource_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "instances.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "instances.0.id",
+						"sbercloud_waf_dedicated_instance.instance_1", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "instances.0.name", name),
+					resource.TestCheckResourceAttrSet(dataSourceName, "instances.0.server_id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "instances.0.service_ip"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "instances.0.run_status"),
+				),
+			},
+		},
+	})
+}
+
+func testAccWafDedicatedInstancesDataSource_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_waf_dedicated_instances" "test" {
+  name = sbercloud_waf_dedicated_instance.instance_1.name
+}
+`, testAccWafDedicatedInstance_conf(name))
+}
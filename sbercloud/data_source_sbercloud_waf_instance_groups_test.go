@@ -0,0 +1,42 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccWafInstanceGroupsDataSource_basic(t *testing.T) {
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	dataSourceName := "data.sbercloud_waf_instance_groups.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafInstanceGroupsDataSource_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "groups.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "groups.0.name", name),
+					resource.TestCheckResourceAttrPair(dataSourceName, "groups.0.vpc_id", "sbercloud_vpc.vpc_1", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccWafInstanceGroupsDataSource_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_waf_instance_groups" "test" {
+  name = sbercloud_waf_instance_group.group_1.name
+}
+`, testAccWafInstanceGroup_conf(name))
+}
@@ -0,0 +1,44 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccWafPoliciesDataSource_basic(t *testing.T) {
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	dataSourceName := "data.sbercloud_waf_policies.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafPoliciesDataSource_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "policies.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "policies.0.id",
+						"sbercloud_waf_policy.policy_1", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "policies.0.name", name),
+					resource.TestCheckResourceAttr(dataSourceName, "policies.0.level", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccWafPoliciesDataSource_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_waf_policies" "test" {
+  name = sbercloud_waf_policy.policy_1.name
+}
+`, testAccWafPolicy_conf(name))
+}
@@ -0,0 +1,45 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccWafReferenceTablesDataSource_basic(t *testing.T) {
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	dataSourceName := "data.sbercloud_waf_reference_tables.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafReferenceTablesDataSource_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "tables.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "tables.0.id",
+						"sbercloud_waf_reference_table.table_1", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "tables.0.type", "url"),
+					resource.TestCheckResourceAttr(dataSourceName, "tables.0.conditions.#", "2"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "tables.0.creation_time"),
+				),
+			},
+		},
+	})
+}
+
+func testAccWafReferenceTablesDataSource_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+data "sbercloud_waf_reference_tables" "test" {
+  name = sbercloud_waf_reference_table.table_1.name
+}
+`, testAccWafReferenceTable_conf(name))
+}
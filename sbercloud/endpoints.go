@@ -0,0 +1,199 @@
+package sbercloud
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// defaultCloud is the domain which the default service endpoints belong to.
+const defaultCloud string = "hc.sbercloud.ru"
+
+//go:generate go run gen_service_catalog_keys.go
+
+// allServiceCatalogKeys lists the service names which can be used as keys of the
+// provider-level `endpoints`: the services of the catalog of the config package, and
+// obs, which is not a catalog entry, but the OBS client honors the custom endpoint as well.
+var allServiceCatalogKeys = append(serviceCatalogKeys, "obs")
+
+func isValidServiceCatalogKey(key string) bool {
+	for _, k := range allServiceCatalogKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func validateProviderEndpoints(v interface{}, k string) (ws []string, errs []error) {
+	for key := range v.(map[string]interface{}) {
+		if !isValidServiceCatalogKey(key) {
+			valid := make([]string, len(allServiceCatalogKeys))
+			copy(valid, allServiceCatalogKeys)
+			sort.Strings(valid)
+			errs = append(errs, fmt.Errorf("%q contains an unknown service %q, valid services are: %s",
+				k, key, strings.Join(valid, ", ")))
+		}
+	}
+	return
+}
+
+func flattenProviderEndpoints(d *schema.ResourceData) (map[string]string, error) {
+	endpoints := d.Get("endpoints").(map[string]interface{})
+	epMap := make(map[string]string)
+
+	for key, val := range endpoints {
+		endpoint := strings.TrimSpace(val.(string))
+		// check empty string
+		if endpoint == "" {
+			return nil, fmt.Errorf("the value of customer endpoint %s must be specified", key)
+		}
+
+		// add prefix "https://" and suffix "/"
+		if !strings.HasPrefix(endpoint, "http") {
+			endpoint = fmt.Sprintf("https://%s", endpoint)
+		}
+		if !strings.HasSuffix(endpoint, "/") {
+			endpoint = fmt.Sprintf("%s/", endpoint)
+		}
+		epMap[key] = endpoint
+	}
+
+	// unify the endpoint which has multi types
+	if endpoint, ok := epMap["iam"]; ok {
+		epMap["identity"] = endpoint
+	}
+	if endpoint, ok := epMap["ecs"]; ok {
+		epMap["ecsv11"] = endpoint
+		epMap["ecsv21"] = endpoint
+	}
+	if endpoint, ok := epMap["cce"]; ok {
+		epMap["cce_addon"] = endpoint
+	}
+	if endpoint, ok := epMap["evs"]; ok {
+		epMap["volumev2"] = endpoint
+	}
+	if endpoint, ok := epMap["vpc"]; ok {
+		epMap["networkv2"] = endpoint
+		epMap["security_group"] = endpoint
+	}
+	// the dedicated mode of WAF is served by the same endpoint, unless it is set explicitly
+	if endpoint, ok := epMap["waf"]; ok {
+		if _, ok := epMap["waf-dedicated"]; !ok {
+			epMap["waf-dedicated"] = endpoint
+		}
+	}
+	// so are the v2 APIs of DWS
+	if endpoint, ok := epMap["dws"]; ok {
+		if _, ok := epMap["dwsV2"]; !ok {
+			epMap["dwsV2"] = endpoint
+		}
+	}
+	// and the dedicated gateways of APIG
+	if endpoint, ok := epMap["apig"]; ok {
+		if _, ok := epMap["apig_v2"]; !ok {
+			epMap["apig_v2"] = endpoint
+		}
+	}
+
+	log.Printf("[DEBUG] customer endpoints: %+v", epMap)
+	return epMap, nil
+}
+
+// serviceHosts identifies the services of the catalog by the host of their endpoint.
+type serviceHosts struct {
+	// the services keyed by the endpoint host in the provider region
+	services map[string]string
+	// the endpoint hosts keyed by the service
+	hosts map[string]string
+	// the services with a custom endpoint
+	endpoints map[string]string
+}
+
+// newServiceHosts resolves the endpoints of the service catalog in the provider region.
+func newServiceHosts(c *config.Config) *serviceHosts {
+	s := &serviceHosts{
+		services:  make(map[string]string),
+		hosts:     make(map[string]string),
+		endpoints: c.Endpoints,
+	}
+
+	for _, service := range allServiceCatalogKeys {
+		host := serviceEndpointHost(c, service)
+		if host == "" {
+			continue
+		}
+		s.hosts[service] = host
+
+		// the service is named after its endpoint if possible, e.g. rds rather than rdsv1
+		if name, ok := s.services[host]; !ok || (name != hostLabel(host) && service == hostLabel(host)) {
+			s.services[host] = service
+		}
+	}
+
+	return s
+}
+
+// service returns the service of the endpoint host. The hosts of other regions
+// are identified by their first label, which is the name of the service.
+func (s *serviceHosts) service(host string) string {
+	if service, ok := s.services[host]; ok {
+		return service
+	}
+	return hostLabel(host)
+}
+
+// host returns the endpoint host of the service in the provider region.
+func (s *serviceHosts) host(service string) string {
+	return s.hosts[service]
+}
+
+// custom returns true if the service has a custom endpoint.
+func (s *serviceHosts) custom(service string) bool {
+	_, ok := s.endpoints[service]
+	return ok
+}
+
+// serviceEndpoint returns the endpoint URL of the service in the region, as used by
+// the service clients: the custom endpoint if set, otherwise the endpoint in the cloud.
+func serviceEndpoint(c *config.Config, service, region string) (string, error) {
+	if endpoint, ok := c.Endpoints[service]; ok {
+		return endpoint, nil
+	}
+	if service == "obs" {
+		return fmt.Sprintf("https://obs.%s.%s/", region, c.Cloud), nil
+	}
+
+	client, err := c.NewServiceClient(service, region)
+	if err != nil {
+		return "", err
+	}
+	return client.Endpoint, nil
+}
+
+func serviceEndpointHost(c *config.Config, service string) string {
+	if service == "obs" {
+		// OBS is not called through the HTTP client of the provider
+		return ""
+	}
+	endpoint, err := serviceEndpoint(c, service, c.Region)
+	if err != nil {
+		log.Printf("[WARN] failed to resolve the endpoint of %s: %s", service, err)
+		return ""
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+func hostLabel(host string) string {
+	return strings.SplitN(host, ".", 2)[0]
+}
@@ -0,0 +1,172 @@
+package sbercloud
+
+import (
+	"testing"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestServiceCatalogKeys(t *testing.T) {
+	client := &golangsdk.ProviderClient{}
+	conf := &config.Config{
+		HwClient:     client,
+		DomainClient: client,
+		Endpoints:    make(map[string]string),
+	}
+
+	for _, key := range allServiceCatalogKeys {
+		if key == "obs" {
+			continue
+		}
+
+		conf.Endpoints[key] = "https://example.com/"
+		if _, err := conf.NewServiceClient(key, "ru-moscow-1"); err != nil {
+			t.Errorf("service %s is not supported by the config package: %s", key, err)
+		}
+	}
+}
+
+func TestWafServiceClients(t *testing.T) {
+	cases := []struct {
+		endpoints map[string]interface{}
+		waf       string
+		dedicated string
+	}{
+		{
+			endpoints: map[string]interface{}{},
+			waf:       "https://waf.ru-moscow-1.hc.sbercloud.ru/v1/" + testIAMProjectID + "/waf/",
+			dedicated: "https://waf.ru-moscow-1.hc.sbercloud.ru/v1/" + testIAMProjectID + "/premium-waf/",
+		},
+		{
+			endpoints: map[string]interface{}{"waf": "waf.internal.example.com"},
+			waf:       "https://waf.internal.example.com/v1/" + testIAMProjectID + "/waf/",
+			dedicated: "https://waf.internal.example.com/v1/" + testIAMProjectID + "/premium-waf/",
+		},
+		{
+			endpoints: map[string]interface{}{
+				"waf":           "waf.internal.example.com",
+				"waf-dedicated": "premium-waf.internal.example.com",
+			},
+			waf:       "https://waf.internal.example.com/v1/" + testIAMProjectID + "/waf/",
+			dedicated: "https://premium-waf.internal.example.com/v1/" + testIAMProjectID + "/premium-waf/",
+		},
+	}
+
+	for _, tc := range cases {
+		conf := testProviderMeta(t, tc.endpoints).(*config.Config)
+
+		client, err := conf.WafV1Client("ru-moscow-1")
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if client.ResourceBase != tc.waf {
+			t.Errorf("expected the WAF resource base %s, got %s", tc.waf, client.ResourceBase)
+		}
+
+		client, err = conf.WafDedicatedV1Client("ru-moscow-1")
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if client.ResourceBase != tc.dedicated {
+			t.Errorf("expected the dedicated WAF resource base %s, got %s", tc.dedicated, client.ResourceBase)
+		}
+	}
+}
+
+func TestApigServiceClients(t *testing.T) {
+	cases := []struct {
+		endpoints map[string]interface{}
+		shared    string
+		dedicated string
+	}{
+		{
+			endpoints: map[string]interface{}{},
+			shared:    "https://apig.ru-moscow-1.hc.sbercloud.ru/v1.0/apigw/",
+			dedicated: "https://apig.ru-moscow-1.hc.sbercloud.ru/v2/" + testIAMProjectID + "/apigw/",
+		},
+		{
+			endpoints: map[string]interface{}{"apig": "apig.internal.example.com"},
+			shared:    "https://apig.internal.example.com/v1.0/apigw/",
+			dedicated: "https://apig.internal.example.com/v2/" + testIAMProjectID + "/apigw/",
+		},
+		{
+			endpoints: map[string]interface{}{
+				"apig":    "apig.internal.example.com",
+				"apig_v2": "apig-v2.internal.example.com",
+			},
+			shared:    "https://apig.internal.example.com/v1.0/apigw/",
+			dedicated: "https://apig-v2.internal.example.com/v2/" + testIAMProjectID + "/apigw/",
+		},
+	}
+
+	for _, tc := range cases {
+		conf := testProviderMeta(t, tc.endpoints).(*config.Config)
+
+		client, err := conf.ApiGatewayV1Client("ru-moscow-1")
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if client.ResourceBase != tc.shared {
+			t.Errorf("expected the shared APIG resource base %s, got %s", tc.shared, client.ResourceBase)
+		}
+
+		client, err = conf.ApigV2Client("ru-moscow-1")
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if client.ResourceBase != tc.dedicated {
+			t.Errorf("expected the dedicated APIG resource base %s, got %s", tc.dedicated, client.ResourceBase)
+		}
+	}
+}
+
+func TestValidateProviderEndpoints(t *testing.T) {
+	endpoints := map[string]interface{}{
+		"sfs-turbo": "sfs-turbo.internal.example.com",
+		"anti-ddos": "antiddos.internal.example.com",
+	}
+	if _, errs := validateProviderEndpoints(endpoints, "endpoints"); len(errs) != 0 {
+		t.Fatalf("expected the services of the catalog to be accepted, got %v", errs)
+	}
+
+	_, errs := validateProviderEndpoints(map[string]interface{}{"sfs_turbo": "example.com"}, "endpoints")
+	if len(errs) != 1 {
+		t.Fatalf("expected the unknown service to be rejected, got %v", errs)
+	}
+}
+
+func TestDwsServiceClients(t *testing.T) {
+	cases := []struct {
+		endpoints map[string]interface{}
+		v2        string
+	}{
+		{
+			endpoints: map[string]interface{}{},
+			v2:        "https://dws.ru-moscow-1.hc.sbercloud.ru/v2/" + testIAMProjectID + "/",
+		},
+		{
+			endpoints: map[string]interface{}{"dws": "dws.internal.example.com"},
+			v2:        "https://dws.internal.example.com/v2/" + testIAMProjectID + "/",
+		},
+		{
+			endpoints: map[string]interface{}{
+				"dws":   "dws.internal.example.com",
+				"dwsV2": "dws-v2.internal.example.com",
+			},
+			v2: "https://dws-v2.internal.example.com/v2/" + testIAMProjectID + "/",
+		},
+	}
+
+	for _, tc := range cases {
+		conf := testProviderMeta(t, tc.endpoints).(*config.Config)
+
+		client, err := conf.DwsV2Client("ru-moscow-1")
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if client.ResourceBase != tc.v2 {
+			t.Errorf("expected the DWS v2 resource base %s, got %s", tc.v2, client.ResourceBase)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+//go:build ignore
+// +build ignore
+
+// This program generates service_catalog_keys.go from the service catalog of the config
+// package of terraform-provider-huaweicloud, which does not export it. Run it with
+// go generate after the config package is updated.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strconv"
+)
+
+const configPackage = "github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+
+func main() {
+	keys, err := serviceCatalogKeys()
+	if err != nil {
+		log.Fatalf("error reading the service catalog: %s", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen_service_catalog_keys.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package sbercloud\n\n")
+	fmt.Fprintf(&buf, "// serviceCatalogKeys are the keys of allServiceCatalog in the config package.\n")
+	fmt.Fprintf(&buf, "var serviceCatalogKeys = []string{\n")
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t%q,\n", key)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("error formatting the service catalog keys: %s", err)
+	}
+	if err := ioutil.WriteFile("service_catalog_keys.go", src, 0644); err != nil {
+		log.Fatalf("error writing the service catalog keys: %s", err)
+	}
+}
+
+// serviceCatalogKeys returns the keys of allServiceCatalog in the order of the source.
+func serviceCatalogKeys() ([]string, error) {
+	pkg, err := build.Import(configPackage, ".", build.FindOnly)
+	if err != nil {
+		return nil, err
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(pkg.Dir, "endpoints.go"), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || spec.Names[0].Name != "allServiceCatalog" {
+			return true
+		}
+		for _, elt := range spec.Values[0].(*ast.CompositeLit).Elts {
+			if key, err := strconv.Unquote(elt.(*ast.KeyValueExpr).Key.(*ast.BasicLit).Value); err == nil {
+				keys = append(keys, key)
+			}
+		}
+		return false
+	})
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("allServiceCatalog was not found in %s", pkg.Dir)
+	}
+	return keys, nil
+}
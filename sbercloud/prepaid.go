@@ -0,0 +1,346 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const chargingModePrePaid = "prePaid"
+
+// the delay before the status of a prePaid resource is polled for the first time
+var prePaidStateDelay = 10 * time.Second
+
+// prePaidResource describes how the yearly/monthly billing of a resource type is managed.
+type prePaidResource struct {
+	// create creates a prePaid resource and sets its ID, it is used when the resource
+	// of the huaweicloud provider only creates pay-per-use resources
+	create func(context.Context, *schema.ResourceData, interface{}) error
+	// unsubscribe is true when the resource of the huaweicloud provider does not
+	// unsubscribe from the prePaid resources on destroy
+	unsubscribe bool
+}
+
+// prePaidResources are the resources which support the yearly/monthly billing.
+var prePaidResources = map[string]prePaidResource{
+	"sbercloud_compute_instance": {},
+	"sbercloud_dcs_instance":     {},
+	"sbercloud_evs_volume": {
+		create:      createPrePaidEvsVolume,
+		unsubscribe: true,
+	},
+	"sbercloud_rds_instance": {},
+	"sbercloud_vpc_eip":      {},
+}
+
+// wrapResourcePrePaid manages the yearly/monthly billing of the resource through BSS:
+// the auto-renewal is changed in place, the resource is renewed when renew_trigger is
+// changed, and the expiration time is exported as expire_time.
+func wrapResourcePrePaid(r *schema.Resource, prePaid prePaidResource) {
+	if _, ok := r.Schema["expire_time"]; ok {
+		// the resource is registered under several names and has been wrapped already
+		return
+	}
+
+	if _, ok := r.Schema["charging_mode"]; !ok {
+		r.Schema["charging_mode"] = schemeChargingMode(nil)
+		r.Schema["period_unit"] = schemaPeriodUnit(nil)
+		r.Schema["period"] = schemaPeriod(nil)
+		r.Schema["auto_renew"] = schemaAutoRenew(nil)
+	}
+	// the schema of the huaweicloud provider may be shared with other resources,
+	// period and period_unit still replace the resource as the subscription can not be changed
+	autoRenew := *r.Schema["auto_renew"]
+	autoRenew.ForceNew = false
+	r.Schema["auto_renew"] = &autoRenew
+
+	// the renewal places a paid order, so it is done on each change of renew_trigger
+	// rather than on a change of its period
+	r.Schema["renew_period"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		RequiredWith: []string{"period_unit"},
+		ValidateFunc: validation.IntBetween(1, 9),
+	}
+	r.Schema["renew_trigger"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		RequiredWith: []string{"renew_period"},
+	}
+	r.Schema["expire_time"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if err := checkPrePaidChanges(d); err != nil {
+			return err
+		}
+
+		if customizeDiff != nil {
+			return customizeDiff(ctx, d, meta)
+		}
+		return nil
+	}
+
+	// the resources of the huaweicloud provider read themselves after creation and update,
+	// so the expiration time is read by all of the wrapped functions
+	withExpireTime := func(fn contextFunc) contextFunc {
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			diags := fn(ctx, d, meta)
+			if diags.HasError() || d.Id() == "" {
+				return diags
+			}
+			return append(diags, readPrePaidExpireTime(d, meta)...)
+		}
+	}
+
+	create, read, update, del := prePaidResourceFuncs(r)
+	createPrePaid := withExpireTime(func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if prePaid.create == nil || d.Get("charging_mode").(string) != chargingModePrePaid {
+			return create(ctx, d, meta)
+		}
+		if err := prePaid.create(ctx, d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+		return read(ctx, d, meta)
+	})
+	updatePrePaid := withExpireTime(func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if d.Get("charging_mode").(string) == chargingModePrePaid {
+			if err := updatePrePaidResource(d, meta); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		return update(ctx, d, meta)
+	})
+	deletePrePaid := del
+	if prePaid.unsubscribe {
+		deletePrePaid = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if d.Get("charging_mode").(string) != chargingModePrePaid {
+				return del(ctx, d, meta)
+			}
+			return deletePrePaidResource(ctx, d, meta, read)
+		}
+	}
+
+	// the functions without context wait for the resources with their own timeouts,
+	// so they are run without the context timeout of the SDK once wrapped
+	if r.Create != nil {
+		r.Create, r.Read, r.Update, r.Delete = nil, nil, nil, nil
+		r.CreateWithoutTimeout = createPrePaid
+		r.ReadWithoutTimeout = withExpireTime(read)
+		r.UpdateWithoutTimeout = updatePrePaid
+		r.DeleteWithoutTimeout = deletePrePaid
+		return
+	}
+	r.CreateContext = createPrePaid
+	r.ReadContext = withExpireTime(read)
+	r.UpdateContext = updatePrePaid
+	r.DeleteContext = deletePrePaid
+}
+
+// prePaidResourceFuncs returns the CRUD functions of the resource as context-aware functions.
+func prePaidResourceFuncs(r *schema.Resource) (create, read, update, del contextFunc) {
+	if r.Create == nil {
+		return r.CreateContext, r.ReadContext, r.UpdateContext, r.DeleteContext
+	}
+	return legacyContextFunc(r.Create), legacyContextFunc(r.Read), legacyContextFunc(r.Update),
+		legacyContextFunc(r.Delete)
+}
+
+func legacyContextFunc(fn func(*schema.ResourceData, interface{}) error) contextFunc {
+	return func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return diag.FromErr(fn(d, meta))
+	}
+}
+
+func checkPrePaidChanges(d *schema.ResourceDiff) error {
+	if d.Id() == "" || d.HasChange("charging_mode") || d.Get("charging_mode").(string) == chargingModePrePaid {
+		return nil
+	}
+
+	for _, key := range []string{"period_unit", "period", "auto_renew", "renew_period", "renew_trigger"} {
+		if d.HasChange(key) {
+			return fmt.Errorf("%s can only be changed in the prePaid charging mode", key)
+		}
+	}
+	return nil
+}
+
+// updatePrePaidResource changes the auto-renewal of the resource and renews it
+// for renew_period when renew_trigger is changed.
+func updatePrePaidResource(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	bssV2Client, err := config.BssV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud bss V2 client: %s", err)
+	}
+
+	if d.HasChange("auto_renew") {
+		if err := setPrePaidAutoRenew(bssV2Client, d.Id(), d.Get("auto_renew").(string) == "true"); err != nil {
+			return fmt.Errorf("Error updating the auto-renewal of the prePaid resource (%s): %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("renew_trigger") && d.Get("renew_trigger").(string) != "" {
+		// the unit of the imported resources is unknown
+		if d.Get("period_unit").(string) == "" || d.Get("renew_period").(int) == 0 {
+			return fmt.Errorf("period_unit and renew_period must be specified to renew the prePaid resource (%s)", d.Id())
+		}
+		if err := renewPrePaidResource(bssV2Client, d); err != nil {
+			return fmt.Errorf("Error renewing the prePaid resource (%s): %s", d.Id(), err)
+		}
+	}
+	return nil
+}
+
+func setPrePaidAutoRenew(client *golangsdk.ServiceClient, id string, enabled bool) error {
+	url := client.ServiceURL("orders", "subscriptions", "resources", "autorenew", id) + "?action_id=autorenew"
+	opts := &golangsdk.RequestOpts{
+		OkCodes: []int{200, 204},
+	}
+
+	log.Printf("[DEBUG] Setting the auto-renewal of the prePaid resource (%s) to %t", id, enabled)
+	var err error
+	if enabled {
+		_, err = client.Post(url, nil, nil, opts)
+	} else {
+		_, err = client.Delete(url, opts)
+	}
+	return err
+}
+
+// prePaidRenewOpts is the request of renewing the prePaid resources.
+type prePaidRenewOpts struct {
+	ResourceIDs []string `json:"resource_ids"`
+	// 2: month, 3: year
+	PeriodType int `json:"period_type"`
+	PeriodNum  int `json:"period_num"`
+	// 0: the resource is frozen after the expiration, 3: it is renewed automatically
+	ExpireMode int `json:"expire_mode"`
+	IsAutoPay  int `json:"is_auto_pay"`
+}
+
+func renewPrePaidResource(client *golangsdk.ServiceClient, d *schema.ResourceData) error {
+	renewOpts := prePaidRenewOpts{
+		ResourceIDs: []string{d.Id()},
+		PeriodType:  2,
+		PeriodNum:   d.Get("renew_period").(int),
+		IsAutoPay:   1,
+	}
+	if d.Get("period_unit").(string) == "year" {
+		renewOpts.PeriodType = 3
+	}
+	if d.Get("auto_renew").(string) == "true" {
+		renewOpts.ExpireMode = 3
+	}
+	log.Printf("[DEBUG] Renew Options of the prePaid resource (%s): %#v", d.Id(), renewOpts)
+
+	var order struct {
+		OrderIDs []string `json:"order_ids"`
+	}
+	_, err := client.Post(client.ServiceURL("orders", "subscriptions", "resources", "renew"), renewOpts, &order,
+		&golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] The prePaid resource (%s) is renewed by the orders %v", d.Id(), order.OrderIDs)
+	return nil
+}
+
+// readPrePaidExpireTime sets the expiration time of the prePaid resource, the errors are
+// returned as warnings because the resource itself has been read.
+func readPrePaidExpireTime(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("charging_mode").(string) != chargingModePrePaid {
+		return diag.FromErr(d.Set("expire_time", ""))
+	}
+
+	config := meta.(*config.Config)
+	expireTime, err := queryPrePaidExpireTime(config, GetRegion(d, config), d.Id())
+	if err != nil {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Error fetching the expiration time of the prePaid resource",
+				Detail:   fmt.Sprintf("Error fetching the expiration time of the prePaid resource (%s): %s", d.Id(), err),
+			},
+		}
+	}
+	return diag.FromErr(d.Set("expire_time", expireTime))
+}
+
+func queryPrePaidExpireTime(c *config.Config, region, id string) (string, error) {
+	bssV2Client, err := c.BssV2Client(region)
+	if err != nil {
+		return "", fmt.Errorf("Error creating SberCloud bss V2 client: %s", err)
+	}
+
+	queryOpts := map[string]interface{}{
+		"resource_ids":       []string{id},
+		"only_main_resource": 1,
+	}
+	var resources struct {
+		Data []struct {
+			ResourceID string `json:"resource_id"`
+			ExpireTime string `json:"expire_time"`
+		} `json:"data"`
+	}
+	_, err = bssV2Client.Post(bssV2Client.ServiceURL("orders", "suscriptions", "resources", "query"), queryOpts,
+		&resources, &golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+	if err != nil {
+		return "", err
+	}
+
+	for _, r := range resources.Data {
+		if r.ResourceID == id {
+			return r.ExpireTime, nil
+		}
+	}
+	return "", fmt.Errorf("the subscription of the resource was not found")
+}
+
+// deletePrePaidResource unsubscribes from the resource and waits until it can not be read.
+func deletePrePaidResource(ctx context.Context, d *schema.ResourceData, meta interface{},
+	read func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) diag.Diagnostics {
+	config := meta.(*config.Config)
+	if err := UnsubscribePrePaidResource(d, config, []string{d.Id()}); err != nil {
+		return diag.Errorf("Error unsubscribing the prePaid resource (%s): %s", d.Id(), err)
+	}
+
+	id := d.Id()
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"ACTIVE"},
+		Target:  []string{"DELETED"},
+		Refresh: func() (interface{}, string, error) {
+			if diags := read(ctx, d, meta); diags.HasError() {
+				return nil, "", fmt.Errorf("%s", diags[0].Summary)
+			}
+			if d.Id() == "" {
+				return d, "DELETED", nil
+			}
+			return d, "ACTIVE", nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      prePaidStateDelay,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("Error waiting for the prePaid resource (%s) to be deleted: %s", id, err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,107 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/common/structs"
+	"github.com/chnsz/golangsdk/openstack/evs/v2/cloudvolumes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// createPrePaidEvsVolume creates a volume in the prePaid charging mode through
+// the EVS v2.1 API, the volumes of the huaweicloud provider are created by the v3 API
+// which only supports the pay-per-use billing.
+func createPrePaidEvsVolume(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*config.Config)
+	if err := validatePrePaidChargeInfo(d); err != nil {
+		return err
+	}
+
+	blockStorageClient, err := config.BlockStorageV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud EVS storage client: %s", err)
+	}
+
+	metadata := make(map[string]string)
+	if v, ok := d.GetOk("kms_id"); ok {
+		metadata["__system__cmkid"] = v.(string)
+		metadata["__system__encrypted"] = "1"
+	}
+	if d.Get("device_type").(string) == "SCSI" {
+		metadata["hw:passthrough"] = "true"
+	}
+	tags := make(map[string]string)
+	for key, value := range d.Get("tags").(map[string]interface{}) {
+		tags[key] = value.(string)
+	}
+
+	createOpts := cloudvolumes.CreateOpts{
+		Volume: cloudvolumes.VolumeOpts{
+			AvailabilityZone:    d.Get("availability_zone").(string),
+			VolumeType:          d.Get("volume_type").(string),
+			Name:                d.Get("name").(string),
+			Description:         d.Get("description").(string),
+			Size:                d.Get("size").(int),
+			BackupID:            d.Get("backup_id").(string),
+			SnapshotID:          d.Get("snapshot_id").(string),
+			ImageID:             d.Get("image_id").(string),
+			Multiattach:         d.Get("multiattach").(bool),
+			Metadata:            metadata,
+			Tags:                tags,
+			EnterpriseProjectID: GetEnterpriseProjectID(d, config),
+		},
+		ChargeInfo: &structs.ChargeInfo{
+			ChargeMode:  chargingModePrePaid,
+			PeriodType:  d.Get("period_unit").(string),
+			PeriodNum:   d.Get("period").(int),
+			IsAutoRenew: d.Get("auto_renew").(string),
+			IsAutoPay:   "true",
+		},
+	}
+	log.Printf("[DEBUG] Create Options: %#v", createOpts)
+
+	job, err := cloudvolumes.Create(blockStorageClient, createOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud EVS volume: %s", err)
+	}
+	if len(job.VolumeIDs) == 0 {
+		return fmt.Errorf("Error creating SberCloud EVS volume: no volume is returned by the order %s", job.OrderID)
+	}
+	log.Printf("[INFO] Volume ID: %s, order ID: %s", job.VolumeIDs[0], job.OrderID)
+
+	// the volume is paid for, so it is stored even if it does not become available
+	d.SetId(job.VolumeIDs[0])
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating"},
+		Target:     []string{"available"},
+		Refresh:    evsVolumeV2StateRefreshFunc(blockStorageClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      prePaidStateDelay,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("Error waiting for volume (%s) to become available: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func evsVolumeV2StateRefreshFunc(client *golangsdk.ServiceClient, id string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		v, err := cloudvolumes.Get(client, id).Extract()
+		if err != nil {
+			// the volume is not listed until the order is processed
+			if _, ok := err.(golangsdk.ErrDefault404); ok {
+				return v, "creating", nil
+			}
+			return nil, "", err
+		}
+		return v, v.Status, nil
+	}
+}
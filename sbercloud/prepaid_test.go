@@ -0,0 +1,480 @@
+package sbercloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testExpireTime = "2026-11-17T20:59:59Z"
+
+// testBSSServer is a local stand-in for the BSS v2 subscription API, it keeps
+// the subscriptions in memory and records the requests.
+type testBSSServer struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	subscriptions map[string]bool
+	requests      []testDMSRequest
+}
+
+func newTestBSSServer(t *testing.T, ids ...string) *testBSSServer {
+	s := &testBSSServer{subscriptions: make(map[string]bool)}
+	for _, id := range ids {
+		s.subscriptions[id] = true
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, testDMSRequest{method: r.Method, path: r.URL.Path, body: body})
+
+		switch {
+		case r.URL.Path == "/v2/orders/suscriptions/resources/query":
+			data := make([]map[string]interface{}, 0)
+			for _, id := range body["resource_ids"].([]interface{}) {
+				if s.subscriptions[id.(string)] {
+					data = append(data, map[string]interface{}{
+						"resource_id": id,
+						"expire_time": testExpireTime,
+					})
+				}
+			}
+			writeTestJSON(w, map[string]interface{}{"data": data, "total_count": len(data)})
+		case r.URL.Path == "/v2/orders/subscriptions/resources/renew":
+			writeTestJSON(w, map[string]interface{}{"order_ids": []string{"CS2110171200ABCDE"}})
+		case r.URL.Path == "/v2/orders/subscriptions/resources/unsubscribe":
+			for _, id := range body["resource_ids"].([]interface{}) {
+				delete(s.subscriptions, id.(string))
+			}
+			writeTestJSON(w, map[string]interface{}{"order_ids": []string{"CS2110171200FGHIJ"}})
+		case strings.HasPrefix(r.URL.Path, "/v2/orders/subscriptions/resources/autorenew/") &&
+			r.URL.Query().Get("action_id") == "autorenew":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testBSSServer) subscribed(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.subscriptions[id]
+}
+
+// lastRequest returns the last request sent to path with method.
+func (s *testBSSServer) lastRequest(method, path string) (testDMSRequest, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.requests) - 1; i >= 0; i-- {
+		if s.requests[i].method == method && s.requests[i].path == path {
+			return s.requests[i], true
+		}
+	}
+	return testDMSRequest{}, false
+}
+
+// countRequests returns the number of requests sent to path with method.
+func (s *testBSSServer) countRequests(method, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, req := range s.requests {
+		if req.method == method && req.path == path {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *testBSSServer) requestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+// testProviderMeta configures the provider against the IAM stand-in and the given endpoints.
+func testProviderMeta(t *testing.T, endpoints map[string]interface{}) interface{} {
+	iam := newTestIAMServer(t)
+	endpoints["iam"] = iam.URL
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "automation-ak",
+		"secret_key":  "automation-sk",
+		"project_id":  testIAMProjectID,
+		"domain_id":   testIAMDomainID,
+		"max_retries": 0,
+		"endpoints":   endpoints,
+	}), "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return meta
+}
+
+// testPrePaidResource returns a wrapped resource whose instances exist as long as
+// they are subscribed to in bss, it counts the calls of the wrapped functions.
+func testPrePaidResource(bss *testBSSServer, calls map[string]int) *schema.Resource {
+	r := &schema.Resource{
+		CreateContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			calls["create"]++
+			d.SetId("instance-1")
+			return nil
+		},
+		ReadContext: func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			calls["read"]++
+			if !bss.subscribed(d.Id()) {
+				d.SetId("")
+			}
+			return nil
+		},
+		UpdateContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			calls["update"]++
+			return nil
+		},
+		DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			calls["delete"]++
+			return nil
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+	wrapResourcePrePaid(r, prePaidResource{unsubscribe: true})
+	return r
+}
+
+func testPrePaidState(t *testing.T, r *schema.Resource, raw map[string]interface{}) *terraform.InstanceState {
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.SetId("instance-1")
+	return d.State()
+}
+
+func testApplyPrePaidResource(t *testing.T, r *schema.Resource, meta interface{}, state *terraform.InstanceState,
+	raw map[string]interface{}) *schema.ResourceData {
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("expected the resource to be updated in place, got %#v", diff.Attributes)
+	}
+
+	state, diags := r.Apply(context.Background(), state, diff, meta)
+	expectNoWarnings(t, diags)
+	return r.Data(state)
+}
+
+func TestWrapResourcePrePaid_schema(t *testing.T) {
+	provider := Provider()
+	for name := range prePaidResources {
+		r, ok := provider.ResourcesMap[name]
+		if !ok {
+			t.Fatalf("%s is not registered", name)
+		}
+		if s := r.Schema["charging_mode"]; s == nil || !s.ForceNew {
+			t.Fatalf("%s: expected charging_mode to replace the resource", name)
+		}
+		for _, key := range []string{"period_unit", "period"} {
+			if s := r.Schema[key]; s == nil || !s.ForceNew {
+				t.Fatalf("%s: expected %s to replace the resource", name, key)
+			}
+		}
+		for _, key := range []string{"auto_renew", "renew_period", "renew_trigger"} {
+			if s := r.Schema[key]; s == nil || s.ForceNew {
+				t.Fatalf("%s: expected %s to be updated in place", name, key)
+			}
+		}
+		if s := r.Schema["expire_time"]; s == nil || !s.Computed {
+			t.Fatalf("%s: expected the computed expire_time", name)
+		}
+	}
+}
+
+func TestWrapResourcePrePaid_sharedSchema(t *testing.T) {
+	autoRenew := schemaAutoRenew(nil)
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"charging_mode": schemeChargingMode(nil),
+			"period_unit":   schemaPeriodUnit(nil),
+			"period":        schemaPeriod(nil),
+			"auto_renew":    autoRenew,
+		},
+	}
+	wrapResourcePrePaid(r, prePaidResource{})
+
+	if r.Schema["auto_renew"].ForceNew {
+		t.Fatalf("expected auto_renew to be updated in place")
+	}
+	if !autoRenew.ForceNew {
+		t.Fatalf("expected the schema of auto_renew shared with other resources to be left unchanged")
+	}
+}
+
+func TestWrapResourcePrePaid_update(t *testing.T) {
+	bss := newTestBSSServer(t, "instance-1")
+	meta := testProviderMeta(t, map[string]interface{}{"bssv2": bss.URL + "/"})
+	calls := make(map[string]int)
+	r := testPrePaidResource(bss, calls)
+
+	state := testPrePaidState(t, r, map[string]interface{}{
+		"charging_mode": "prePaid",
+		"period_unit":   "year",
+		"period":        2,
+		"auto_renew":    "false",
+	})
+
+	// the change of renew_period is only saved to the state
+	d := testApplyPrePaidResource(t, r, meta, state, map[string]interface{}{
+		"charging_mode": "prePaid",
+		"period_unit":   "year",
+		"period":        2,
+		"auto_renew":    "true",
+		"renew_period":  3,
+	})
+
+	if _, ok := bss.lastRequest(http.MethodPost, "/v2/orders/subscriptions/resources/autorenew/instance-1"); !ok {
+		t.Fatalf("expected the auto-renewal to be enabled")
+	}
+	if _, ok := bss.lastRequest(http.MethodPost, "/v2/orders/subscriptions/resources/renew"); ok {
+		t.Fatalf("expected the change of renew_period not to renew the resource")
+	}
+	if calls["update"] != 1 {
+		t.Fatalf("expected the wrapped update to be called once, got %d", calls["update"])
+	}
+	if d.Get("expire_time").(string) != testExpireTime {
+		t.Fatalf("expected the expiration time to be read, got %q", d.Get("expire_time"))
+	}
+
+	d = testApplyPrePaidResource(t, r, meta, d.State(), map[string]interface{}{
+		"charging_mode": "prePaid",
+		"period_unit":   "year",
+		"period":        2,
+		"auto_renew":    "true",
+		"renew_period":  3,
+		"renew_trigger": "2026-10",
+	})
+	renew, ok := bss.lastRequest(http.MethodPost, "/v2/orders/subscriptions/resources/renew")
+	if !ok {
+		t.Fatalf("expected the resource to be renewed")
+	}
+	if renew.body["period_type"] != 3.0 || renew.body["period_num"] != 3.0 || renew.body["is_auto_pay"] != 1.0 {
+		t.Fatalf("unexpected renew request: %v", renew.body)
+	}
+
+	// disable the auto-renewal without renewing
+	renewals := bss.countRequests(http.MethodPost, "/v2/orders/subscriptions/resources/renew")
+	d = testApplyPrePaidResource(t, r, meta, d.State(), map[string]interface{}{
+		"charging_mode": "prePaid",
+		"period_unit":   "year",
+		"period":        2,
+		"auto_renew":    "false",
+		"renew_period":  3,
+		"renew_trigger": "2026-10",
+	})
+	if _, ok := bss.lastRequest(http.MethodDelete, "/v2/orders/subscriptions/resources/autorenew/instance-1"); !ok {
+		t.Fatalf("expected the auto-renewal to be disabled")
+	}
+	if n := bss.countRequests(http.MethodPost, "/v2/orders/subscriptions/resources/renew"); n != renewals {
+		t.Fatalf("expected the resource not to be renewed again")
+	}
+
+	// renew again for the same period
+	testApplyPrePaidResource(t, r, meta, d.State(), map[string]interface{}{
+		"charging_mode": "prePaid",
+		"period_unit":   "year",
+		"period":        2,
+		"auto_renew":    "false",
+		"renew_period":  3,
+		"renew_trigger": "2027-10",
+	})
+	if n := bss.countRequests(http.MethodPost, "/v2/orders/subscriptions/resources/renew"); n != renewals+1 {
+		t.Fatalf("expected the change of renew_trigger to renew the resource again")
+	}
+}
+
+func TestWrapResourcePrePaid_importedPeriod(t *testing.T) {
+	bss := newTestBSSServer(t, "instance-1")
+	meta := testProviderMeta(t, map[string]interface{}{"bssv2": bss.URL + "/"})
+	r := testPrePaidResource(bss, make(map[string]int))
+
+	state := testPrePaidState(t, r, map[string]interface{}{
+		"charging_mode": "prePaid",
+	})
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"charging_mode": "prePaid",
+		"renew_period":  1,
+		"renew_trigger": "2026-10",
+	}), meta)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, diags := r.Apply(context.Background(), state, diff, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "period_unit and renew_period must be specified") {
+		t.Fatalf("expected the renewal of the imported resource to fail, got %v", diags)
+	}
+	if _, ok := bss.lastRequest(http.MethodPost, "/v2/orders/subscriptions/resources/renew"); ok {
+		t.Fatalf("expected the imported resource not to be renewed")
+	}
+}
+
+func TestWrapResourcePrePaid_postPaid(t *testing.T) {
+	bss := newTestBSSServer(t)
+	meta := testProviderMeta(t, map[string]interface{}{"bssv2": bss.URL + "/"})
+	r := testPrePaidResource(bss, make(map[string]int))
+
+	state := testPrePaidState(t, r, map[string]interface{}{
+		"charging_mode": "postPaid",
+	})
+	_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"charging_mode": "postPaid",
+		"auto_renew":    "true",
+	}), meta)
+	if err == nil || !strings.Contains(err.Error(), "auto_renew can only be changed in the prePaid charging mode") {
+		t.Fatalf("expected the change of auto_renew to be rejected, got %v", err)
+	}
+
+	d := r.Data(state)
+	expectNoWarnings(t, r.ReadContext(context.Background(), d, meta))
+	if bss.requestCount() != 0 {
+		t.Fatalf("expected the pay-per-use resource not to be queried in bss")
+	}
+}
+
+func TestWrapResourcePrePaid_delete(t *testing.T) {
+	bss := newTestBSSServer(t, "instance-1")
+	meta := testProviderMeta(t, map[string]interface{}{"bssv2": bss.URL + "/"})
+	calls := make(map[string]int)
+	r := testPrePaidResource(bss, calls)
+
+	stateDelay := prePaidStateDelay
+	prePaidStateDelay = 0
+	defer func() { prePaidStateDelay = stateDelay }()
+
+	d := r.Data(testPrePaidState(t, r, map[string]interface{}{
+		"charging_mode": "prePaid",
+		"period_unit":   "month",
+		"period":        1,
+	}))
+	expectNoWarnings(t, r.DeleteContext(context.Background(), d, meta))
+
+	req, ok := bss.lastRequest(http.MethodPost, "/v2/orders/subscriptions/resources/unsubscribe")
+	if !ok || req.body["unsubscribe_type"] != 1.0 {
+		t.Fatalf("expected the resource to be unsubscribed, got %v", req.body)
+	}
+	if calls["delete"] != 0 || d.Id() != "" {
+		t.Fatalf("expected the prePaid resource to be deleted by the unsubscription")
+	}
+}
+
+func TestResourceEvsVolumePrePaidCreate(t *testing.T) {
+	var mu sync.Mutex
+	var createBody map[string]interface{}
+	evs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2.1/"+testIAMProjectID+"/cloudvolumes":
+			_ = json.NewDecoder(r.Body).Decode(&createBody)
+			writeTestJSON(w, map[string]interface{}{
+				"order_id":   "CS2110171200KLMNO",
+				"volume_ids": []string{"volume-1"},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/"+testIAMProjectID+"/cloudvolumes/volume-1":
+			writeTestJSON(w, map[string]interface{}{
+				"volume": map[string]interface{}{"id": "volume-1", "status": "available"},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer evs.Close()
+	meta := testProviderMeta(t, map[string]interface{}{"volumev2": evs.URL + "/"})
+
+	stateDelay := prePaidStateDelay
+	prePaidStateDelay = 0
+	defer func() { prePaidStateDelay = stateDelay }()
+
+	r := Provider().ResourcesMap["sbercloud_evs_volume"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"availability_zone": "ru-moscow-1a",
+		"volume_type":       "SSD",
+		"size":              100,
+		"charging_mode":     "prePaid",
+		"period_unit":       "month",
+		"period":            3,
+		"auto_renew":        "true",
+	})
+	if err := createPrePaidEvsVolume(context.Background(), d, meta); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if d.Id() != "volume-1" {
+		t.Fatalf("expected the volume of the order to be stored, got %q", d.Id())
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	charge := createBody["bssParam"].(map[string]interface{})
+	if charge["charge_mode"] != "prePaid" || charge["period_type"] != "month" || charge["period_num"] != 3.0 ||
+		charge["is_auto_renew"] != "true" || charge["is_auto_pay"] != "true" {
+		t.Fatalf("unexpected charging options: %v", charge)
+	}
+}
+
+func TestWrapResourcePrePaid_legacy(t *testing.T) {
+	var created bool
+	r := &schema.Resource{
+		Create: func(d *schema.ResourceData, _ interface{}) error {
+			created = true
+			d.SetId("instance-1")
+			return nil
+		},
+		Read:   func(_ *schema.ResourceData, _ interface{}) error { return nil },
+		Update: func(_ *schema.ResourceData, _ interface{}) error { return nil },
+		Delete: func(_ *schema.ResourceData, _ interface{}) error { return nil },
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+	wrapResourcePrePaid(r, prePaidResource{})
+	if r.Create != nil || r.CreateContext != nil || r.CreateWithoutTimeout == nil {
+		t.Fatalf("expected the legacy functions to be run without the timeout of the SDK")
+	}
+
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "test",
+	}), nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if _, diags := r.Apply(context.Background(), nil, diff, nil); diags.HasError() {
+		t.Fatalf("err: %v", diags)
+	}
+	if !created {
+		t.Fatalf("expected the legacy create to be called")
+	}
+}
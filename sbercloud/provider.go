@@ -1,12 +1,20 @@
 package sbercloud
 
 import (
+	"context"
+	"fmt"
 	"sync"
 
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/mutexkv"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/apig"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/cbr"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/cce"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/css"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/dcs"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/dds"
@@ -16,7 +24,9 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/dws"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/fgs"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/iam"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/swr"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/vpc"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/waf"
 )
 
 // This is a global MutexKV for use within this plugin.
@@ -42,28 +52,57 @@ func Provider() *schema.Provider {
 				RequiredWith: []string{"access_key"},
 			},
 
+			"security_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("SBC_SECURITY_TOKEN", nil),
+				Description: descriptions["security_token"],
+			},
+
 			"auth_url": {
-				Type:     schema.TypeString,
-				Optional: true,
-				DefaultFunc: schema.EnvDefaultFunc(
-					"SBC_AUTH_URL", "https://iam.ru-moscow-1.hc.sbercloud.ru/v3"),
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SBC_AUTH_URL", nil),
 				Description: descriptions["auth_url"],
 			},
 
+			"cloud": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SBC_CLOUD", defaultCloud),
+				Description: descriptions["cloud"],
+			},
+
+			"endpoints": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateProviderEndpoints,
+				Description:  descriptions["endpoints"],
+			},
+
 			"region": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  descriptions["region"],
-				DefaultFunc:  schema.EnvDefaultFunc("SBC_REGION_NAME", nil),
-				InputDefault: "ru-moscow-1",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["region"],
+				DefaultFunc: schema.EnvDefaultFunc("SBC_REGION_NAME", nil),
 			},
 
 			"user_name": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				DefaultFunc:  schema.EnvDefaultFunc("SBC_USERNAME", ""),
+				DefaultFunc:  schema.EnvDefaultFunc("SBC_USERNAME", nil),
 				Description:  descriptions["user_name"],
-				RequiredWith: []string{"password", "account_name"},
+				RequiredWith: []string{"password"},
+			},
+
+			"user_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("SBC_USER_ID", nil),
+				Description:   descriptions["user_id"],
+				ConflictsWith: []string{"user_name"},
 			},
 
 			"project_name": {
@@ -75,13 +114,35 @@ func Provider() *schema.Provider {
 				Description: descriptions["project_name"],
 			},
 
+			"project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SBC_PROJECT_ID", ""),
+				Description: descriptions["project_id"],
+			},
+
+			"region_project_ids": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: descriptions["region_project_ids"],
+			},
+
 			"password": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Sensitive:    true,
-				DefaultFunc:  schema.EnvDefaultFunc("SBC_PASSWORD", ""),
-				Description:  descriptions["password"],
-				RequiredWith: []string{"user_name", "account_name"},
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("SBC_PASSWORD", nil),
+				Description: descriptions["password"],
+			},
+
+			"token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("SBC_AUTH_TOKEN", nil),
+				Description:   descriptions["token"],
+				ConflictsWith: []string{"password", "access_key", "secret_key"},
 			},
 
 			"account_name": {
@@ -89,9 +150,38 @@ func Provider() *schema.Provider {
 				Optional: true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"SBC_ACCOUNT_NAME",
-				}, ""),
-				Description:  descriptions["account_name"],
-				RequiredWith: []string{"password", "user_name"},
+				}, nil),
+				Description: descriptions["account_name"],
+			},
+
+			"domain_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SBC_DOMAIN_ID", ""),
+				Description: descriptions["domain_id"],
+			},
+
+			"agency_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SBC_AGENCY_NAME", nil),
+				Description:  descriptions["agency_name"],
+				RequiredWith: []string{"agency_domain_name"},
+			},
+
+			"agency_domain_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SBC_AGENCY_DOMAIN_NAME", nil),
+				Description:  descriptions["agency_domain_name"],
+				RequiredWith: []string{"agency_name"},
+			},
+
+			"delegated_project": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SBC_DELEGATED_PROJECT", ""),
+				Description: descriptions["delegated_project"],
 			},
 
 			"insecure": {
@@ -101,6 +191,44 @@ func Provider() *schema.Provider {
 				Description: descriptions["insecure"],
 			},
 
+			"shared_config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SBC_SHARED_CONFIG_FILE", ""),
+				Description: descriptions["shared_config_file"],
+			},
+
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SBC_PROFILE", ""),
+				Description: descriptions["profile"],
+			},
+
+			"cacert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SBC_CACERT", ""),
+				Description: descriptions["cacert_file"],
+			},
+
+			"cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SBC_CERT", nil),
+				Description:  descriptions["cert"],
+				RequiredWith: []string{"key"},
+			},
+
+			"key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("SBC_KEY", nil),
+				Description:  descriptions["key"],
+				RequiredWith: []string{"cert"},
+			},
+
 			"enterprise_project_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -114,46 +242,216 @@ func Provider() *schema.Provider {
 				Description: descriptions["max_retries"],
 				DefaultFunc: schema.EnvDefaultFunc("SBC_MAX_RETRIES", 5),
 			},
+
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["retry"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_wait": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      defaultRetryMinWait,
+							ValidateFunc: validateDuration,
+						},
+						"max_wait": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      defaultRetryMaxWait,
+							ValidateFunc: validateDuration,
+						},
+						"jitter": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"deadline": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      defaultRetryDeadline,
+							ValidateFunc: validateDuration,
+						},
+						"retryable_status_codes": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(400, 599),
+							},
+						},
+					},
+				},
+			},
+
+			"rate_limit": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["rate_limit"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"requests_per_second": {
+							Type:         schema.TypeFloat,
+							Optional:     true,
+							ValidateFunc: validation.FloatAtLeast(0),
+						},
+						"max_in_flight": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"service": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+										// the OBS client is built by the OBS SDK, whose requests are not limited
+										ValidateFunc: validation.All(
+											validation.StringInSlice(allServiceCatalogKeys, false),
+											validation.StringNotInSlice([]string{"obs"}, false),
+										),
+									},
+									"requests_per_second": {
+										Type:         schema.TypeFloat,
+										Optional:     true,
+										ValidateFunc: validation.FloatAtLeast(0),
+									},
+									"max_in_flight": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"api_log": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["api_log"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"format": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "jsonl",
+							ValidateFunc: validation.StringInSlice([]string{"jsonl"}, false),
+						},
+					},
+				},
+			},
+
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["default_tags"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"check_quotas": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: descriptions["check_quotas"],
+			},
+
+			"ignore_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["ignore_tags"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"keys": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"key_prefixes": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"sbercloud_availability_zones":     huaweicloud.DataSourceAvailabilityZones(),
-			"sbercloud_cce_cluster":            huaweicloud.DataSourceCCEClusterV3(),
-			"sbercloud_cce_node":               huaweicloud.DataSourceCCENodeV3(),
-			"sbercloud_cce_node_pool":          huaweicloud.DataSourceCCENodePoolV3(),
-			"sbercloud_cdm_flavors":            huaweicloud.DataSourceCdmFlavorV1(),
-			"sbercloud_compute_flavors":        huaweicloud.DataSourceEcsFlavors(),
-			"sbercloud_dcs_az":                 deprecated.DataSourceDcsAZV1(),
-			"sbercloud_dcs_maintainwindow":     dcs.DataSourceDcsMaintainWindow(),
-			"sbercloud_dcs_product":            deprecated.DataSourceDcsProductV1(),
-			"sbercloud_dds_flavors":            dds.DataSourceDDSFlavorV3(),
-			"sbercloud_dis_partition":          huaweicloud.DataSourceDisPartitionV2(),
-			"sbercloud_dms_az":                 huaweicloud.DataSourceDmsAZV1(),
-			"sbercloud_dms_product":            huaweicloud.DataSourceDmsProductV1(),
-			"sbercloud_dms_maintainwindow":     huaweicloud.DataSourceDmsMaintainWindowV1(),
-			"sbercloud_identity_role":          iam.DataSourceIdentityRoleV3(),
-			"sbercloud_identity_custom_role":   iam.DataSourceIdentityCustomRole(),
-			"sbercloud_identity_group":         iam.DataSourceIdentityGroup(),
-			"sbercloud_images_image":           huaweicloud.DataSourceImagesImageV2(),
-			"sbercloud_kms_key":                huaweicloud.DataSourceKmsKeyV1(),
-			"sbercloud_kms_data_key":           huaweicloud.DataSourceKmsDataKeyV1(),
-			"sbercloud_nat_gateway":            huaweicloud.DataSourceNatGatewayV2(),
-			"sbercloud_networking_port":        huaweicloud.DataSourceNetworkingPortV2(),
-			"sbercloud_networking_secgroup":    huaweicloud.DataSourceNetworkingSecGroupV2(),
-			"sbercloud_obs_bucket_object":      huaweicloud.DataSourceObsBucketObject(),
-			"sbercloud_rds_flavors":            huaweicloud.DataSourceRdsFlavorV3(),
-			"sbercloud_sfs_file_system":        huaweicloud.DataSourceSFSFileSystemV2(),
-			"sbercloud_vpc":                    vpc.DataSourceVpcV1(),
-			"sbercloud_vpcs":                   vpc.DataSourceVpcs(),
-			"sbercloud_vpc_bandwidth":          vpc.DataSourceBandWidth(),
-			"sbercloud_vpc_eip":                vpc.DataSourceVpcEip(),
-			"sbercloud_vpc_ids":                vpc.DataSourceVpcIdsV1(),
-			"sbercloud_vpc_peering_connection": vpc.DataSourceVpcPeeringConnectionV2(),
-			"sbercloud_vpc_route":              vpc.DataSourceVpcRouteV2(),
-			"sbercloud_vpc_route_table":        vpc.DataSourceVPCRouteTable(),
-			"sbercloud_vpc_subnet":             vpc.DataSourceVpcSubnetV1(),
-			"sbercloud_vpc_subnets":            vpc.DataSourceVpcSubnets(),
-			"sbercloud_vpc_subnet_ids":         vpc.DataSourceVpcSubnetIdsV1(),
+			"sbercloud_api_gateway_apis":        DataSourceAPIGatewayAPIs(),
+			"sbercloud_availability_zones":      huaweicloud.DataSourceAvailabilityZones(),
+			"sbercloud_caller_identity":         DataSourceCallerIdentity(),
+			"sbercloud_cbr_backup":              DataSourceCBRBackup(),
+			"sbercloud_cbr_vaults":              DataSourceCBRVaults(),
+			"sbercloud_cce_addon_template":      huaweicloud.DataSourceCCEAddonTemplateV3(),
+			"sbercloud_cce_cluster":             huaweicloud.DataSourceCCEClusterV3(),
+			"sbercloud_cce_node":                huaweicloud.DataSourceCCENodeV3(),
+			"sbercloud_cce_node_pool":           huaweicloud.DataSourceCCENodePoolV3(),
+			"sbercloud_cdm_flavors":             huaweicloud.DataSourceCdmFlavorV1(),
+			"sbercloud_compute_flavors":         huaweicloud.DataSourceEcsFlavors(),
+			"sbercloud_dcs_az":                  deprecated.DataSourceDcsAZV1(),
+			"sbercloud_dcs_maintainwindow":      dcs.DataSourceDcsMaintainWindow(),
+			"sbercloud_dcs_product":             deprecated.DataSourceDcsProductV1(),
+			"sbercloud_dds_flavors":             dds.DataSourceDDSFlavorV3(),
+			"sbercloud_dis_partition":           huaweicloud.DataSourceDisPartitionV2(),
+			"sbercloud_dms_az":                  huaweicloud.DataSourceDmsAZV1(),
+			"sbercloud_dms_product":             huaweicloud.DataSourceDmsProductV1(),
+			"sbercloud_dms_maintainwindow":      huaweicloud.DataSourceDmsMaintainWindowV1(),
+			"sbercloud_identity_role":           iam.DataSourceIdentityRoleV3(),
+			"sbercloud_identity_custom_role":    iam.DataSourceIdentityCustomRole(),
+			"sbercloud_identity_group":          iam.DataSourceIdentityGroup(),
+			"sbercloud_images_image":            huaweicloud.DataSourceImagesImageV2(),
+			"sbercloud_kms_key":                 huaweicloud.DataSourceKmsKeyV1(),
+			"sbercloud_kms_data_key":            huaweicloud.DataSourceKmsDataKeyV1(),
+			"sbercloud_nat_gateway":             huaweicloud.DataSourceNatGatewayV2(),
+			"sbercloud_networking_port":         huaweicloud.DataSourceNetworkingPortV2(),
+			"sbercloud_networking_secgroup":     huaweicloud.DataSourceNetworkingSecGroupV2(),
+			"sbercloud_obs_bucket_object":       huaweicloud.DataSourceObsBucketObject(),
+			"sbercloud_quotas":                  DataSourceQuotas(),
+			"sbercloud_rds_flavors":             huaweicloud.DataSourceRdsFlavorV3(),
+			"sbercloud_regions":                 DataSourceRegions(),
+			"sbercloud_resource_price":          DataSourceResourcePrice(),
+			"sbercloud_service_endpoints":       DataSourceServiceEndpoints(),
+			"sbercloud_sfs_file_system":         huaweicloud.DataSourceSFSFileSystemV2(),
+			"sbercloud_swr_image_tags":          DataSourceSWRImageTags(),
+			"sbercloud_swr_repositories":        DataSourceSWRRepositories(),
+			"sbercloud_vpc":                     vpc.DataSourceVpcV1(),
+			"sbercloud_vpcs":                    vpc.DataSourceVpcs(),
+			"sbercloud_vpc_bandwidth":           vpc.DataSourceBandWidth(),
+			"sbercloud_vpc_eip":                 vpc.DataSourceVpcEip(),
+			"sbercloud_vpc_ids":                 vpc.DataSourceVpcIdsV1(),
+			"sbercloud_vpc_peering_connection":  vpc.DataSourceVpcPeeringConnectionV2(),
+			"sbercloud_vpc_route":               vpc.DataSourceVpcRouteV2(),
+			"sbercloud_vpc_route_table":         vpc.DataSourceVPCRouteTable(),
+			"sbercloud_vpc_subnet":              vpc.DataSourceVpcSubnetV1(),
+			"sbercloud_vpc_subnets":             vpc.DataSourceVpcSubnets(),
+			"sbercloud_vpc_subnet_ids":          vpc.DataSourceVpcSubnetIdsV1(),
+			"sbercloud_waf_certificate":         waf.DataSourceWafCertificateV1(),
+			"sbercloud_waf_dedicated_instances": waf.DataSourceWafDedicatedInstancesV1(),
+			"sbercloud_waf_instance_groups":     waf.DataSourceWafInstanceGroups(),
+			"sbercloud_waf_policies":            waf.DataSourceWafPoliciesV1(),
+			"sbercloud_waf_reference_tables":    waf.DataSourceWafReferenceTablesV1(),
 			// Legacy
 			"sbercloud_identity_role_v3": iam.DataSourceIdentityRoleV3(),
 		},
@@ -161,13 +459,29 @@ func Provider() *schema.Provider {
 		ResourcesMap: map[string]*schema.Resource{
 			"sbercloud_api_gateway_api":                 huaweicloud.ResourceAPIGatewayAPI(),
 			"sbercloud_api_gateway_group":               huaweicloud.ResourceAPIGatewayGroup(),
+			"sbercloud_apig_api":                        apig.ResourceApigAPIV2(),
+			"sbercloud_apig_api_publishment":            apig.ResourceApigApiPublishment(),
+			"sbercloud_apig_application":                apig.ResourceApigApplicationV2(),
+			"sbercloud_apig_custom_authorizer":          apig.ResourceApigCustomAuthorizerV2(),
+			"sbercloud_apig_environment":                apig.ResourceApigEnvironmentV2(),
+			"sbercloud_apig_group":                      apig.ResourceApigGroupV2(),
+			"sbercloud_apig_instance":                   apig.ResourceApigInstanceV2(),
+			"sbercloud_apig_response":                   apig.ResourceApigResponseV2(),
+			"sbercloud_apig_throttling_policy":          apig.ResourceApigThrottlingPolicyV2(),
+			"sbercloud_apig_vpc_channel":                apig.ResourceApigVpcChannelV2(),
 			"sbercloud_as_configuration":                huaweicloud.ResourceASConfiguration(),
 			"sbercloud_as_group":                        huaweicloud.ResourceASGroup(),
 			"sbercloud_as_policy":                       huaweicloud.ResourceASPolicy(),
 			"sbercloud_css_cluster":                     css.ResourceCssCluster(),
+			"sbercloud_cbr_backup_restore":              ResourceCBRBackupRestore(),
+			"sbercloud_cbr_policy":                      cbr.ResourceCBRPolicyV3(),
+			"sbercloud_cbr_vault":                       cbr.ResourceCBRVaultV3(),
+			"sbercloud_cce_addon":                       ResourceCCEAddon(),
 			"sbercloud_cce_cluster":                     huaweicloud.ResourceCCEClusterV3(),
+			"sbercloud_cce_namespace":                   cce.ResourceCCENamespaceV1(),
 			"sbercloud_cce_node":                        huaweicloud.ResourceCCENodeV3(),
 			"sbercloud_cce_node_pool":                   huaweicloud.ResourceCCENodePool(),
+			"sbercloud_cce_pvc":                         cce.ResourceCcePersistentVolumeClaimsV1(),
 			"sbercloud_cdm_cluster":                     huaweicloud.ResourceCdmClusterV1(),
 			"sbercloud_compute_instance":                huaweicloud.ResourceComputeInstanceV2(),
 			"sbercloud_compute_interface_attach":        huaweicloud.ResourceComputeInterfaceAttachV2(),
@@ -229,6 +543,10 @@ func Provider() *schema.Provider {
 			"sbercloud_sfs_turbo":                       huaweicloud.ResourceSFSTurbo(),
 			"sbercloud_smn_subscription":                huaweicloud.ResourceSubscription(),
 			"sbercloud_smn_topic":                       huaweicloud.ResourceTopic(),
+			"sbercloud_swr_organization":                swr.ResourceSWROrganization(),
+			"sbercloud_swr_organization_permissions":    swr.ResourceSWROrganizationPermissions(),
+			"sbercloud_swr_repository":                  swr.ResourceSWRRepository(),
+			"sbercloud_swr_repository_sharing":          swr.ResourceSWRRepositorySharing(),
 			"sbercloud_vpc":                             vpc.ResourceVirtualPrivateCloudV1(),
 			"sbercloud_vpc_bandwidth":                   vpc.ResourceVpcBandWidthV2(),
 			"sbercloud_vpc_eip":                         vpc.ResourceVpcEIPV1(),
@@ -237,6 +555,16 @@ func Provider() *schema.Provider {
 			"sbercloud_vpc_route":                       vpc.ResourceVPCRouteV2(),
 			"sbercloud_vpc_route_table":                 vpc.ResourceVPCRouteTable(),
 			"sbercloud_vpc_subnet":                      vpc.ResourceVpcSubnetV1(),
+			"sbercloud_waf_certificate":                 waf.ResourceWafCertificateV1(),
+			"sbercloud_waf_dedicated_domain":            waf.ResourceWafDedicatedDomainV1(),
+			"sbercloud_waf_dedicated_instance":          waf.ResourceWafDedicatedInstance(),
+			"sbercloud_waf_domain":                      waf.ResourceWafDomainV1(),
+			"sbercloud_waf_instance_group":              waf.ResourceWafInstanceGroup(),
+			"sbercloud_waf_policy":                      waf.ResourceWafPolicyV1(),
+			"sbercloud_waf_reference_table":             waf.ResourceWafReferenceTableV1(),
+			"sbercloud_waf_rule_blacklist":              waf.ResourceWafRuleBlackListV1(),
+			"sbercloud_waf_rule_data_masking":           waf.ResourceWafRuleDataMaskingV1(),
+			"sbercloud_waf_rule_web_tamper_protection":  waf.ResourceWafRuleWebTamperProtectionV1(),
 			// Legacy
 			"sbercloud_identity_role_assignment_v3":  iam.ResourceIdentityRoleAssignmentV3(),
 			"sbercloud_identity_user_v3":             iam.ResourceIdentityUserV3(),
@@ -245,14 +573,28 @@ func Provider() *schema.Provider {
 		},
 	}
 
-	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+	settings := &providerSettings{}
+	wrapResources(provider, settings)
+
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
 			// Terraform 0.12 introduced this field to the protocol
 			// We can therefore assume that if it's missing it's 0.10 or 0.11
 			terraformVersion = "0.11+compatible"
 		}
-		return configureProvider(d, terraformVersion)
+		conf, err := configureProvider(d, terraformVersion)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		*settings = expandProviderSettings(d)
+
+		// the requests are sent with the stop context of the provider,
+		// so their retry and rate limit waits end when Terraform is interrupted
+		if stop, ok := schema.StopContext(ctx); ok {
+			setClientContext(conf.(*config.Config), stop)
+		}
+		return conf, nil
 	}
 
 	return provider
@@ -268,38 +610,125 @@ func init() {
 
 		"user_name": "Username to login with.",
 
+		"user_id": "The ID of the user to login with.",
+
 		"project_name": "The name of the Project to login with.",
 
+		"project_id": "The ID of the Project to login with.",
+
+		"region_project_ids": "The IDs of the projects of other regions, keyed by the region name.",
+
 		"password": "Password to login with.",
 
 		"account_name": "The name of the Account to login with.",
 
+		"domain_id": "The ID of the Account to login with.",
+
+		"token": "Authentication token to use as an alternative to username/password or AK/SK.",
+
+		"security_token": "The security token to authenticate with a temporary security credential.",
+
+		"agency_name": "The name of agency",
+
+		"agency_domain_name": "The name of domain who created the agency (Identity v3).",
+
+		"delegated_project": "The name of delegated project (Identity v3).",
+
 		"insecure": "Trust self-signed certificates.",
+
+		"shared_config_file": "The path to the shared config file, defaults to ~/.sbercloud/config.",
+
+		"profile": "The profile name of the shared config file to use.",
+
+		"cacert_file": "A Custom CA certificate.",
+
+		"cert": "A client certificate to authenticate with.",
+
+		"key": "A client private key to authenticate with.",
+
+		"cloud": "The endpoint of cloud provider, defaults to hc.sbercloud.ru",
+
+		"endpoints": "The custom endpoints used to override the default endpoint URL.",
+
+		"default_tags": "The tags which are added to all resources with tags.",
+
+		"ignore_tags": "The tags which are managed outside of Terraform and ignored by all resources.",
+
+		"check_quotas": "Whether to warn at plan time when the new resources would exceed the quotas of the project.",
+
+		"retry": "The retry policy of the API requests which are throttled or fail with a transient error.",
+
+		"rate_limit": "The limits of the API requests sent to each service.",
+
+		"api_log": "The file which the API requests of the resources and data sources are recorded in, " +
+			"with the secrets masked.",
 	}
 }
 
 func configureProvider(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
-	var project_name string
+	var identityEndpoint string
+
+	// the arguments which are not set can be read from the shared config file
+	profile, err := loadSharedConfigProfile(d)
+	if err != nil {
+		return nil, err
+	}
+	profile = profileCredentials(d, profile)
+
+	region := getProviderString(d, profile, "region")
+	if region == "" {
+		return nil, fmt.Errorf("\"region\" must be specified in the provider block, " +
+			"the SBC_REGION_NAME environment variable or the shared config profile")
+	}
+	cloud := d.Get("cloud").(string)
 
 	// Use region as project_name if it's not set
-	if v, ok := d.GetOk("project_name"); ok && v.(string) != "" {
-		project_name = v.(string)
+	project_name := getProviderString(d, profile, "project_name")
+	if project_name == "" {
+		project_name = region
+	}
+
+	// Use region as delegated_project if it's not set
+	delegated_project := getProviderString(d, profile, "delegated_project")
+	if delegated_project == "" {
+		delegated_project = region
+	}
+
+	// use auth_url as identityEndpoint if provided
+	if v, ok := d.GetOk("auth_url"); ok {
+		identityEndpoint = v.(string)
 	} else {
-		project_name = d.Get("region").(string)
+		// use cloud as basis for identityEndpoint
+		if cloud == defaultCloud {
+			identityEndpoint = fmt.Sprintf("https://iam.ru-moscow-1.%s/v3", cloud)
+		} else {
+			identityEndpoint = fmt.Sprintf("https://iam.%s.%s/v3", region, cloud)
+		}
 	}
 
 	config := config.Config{
-		AccessKey:           d.Get("access_key").(string),
-		SecretKey:           d.Get("secret_key").(string),
-		DomainName:          d.Get("account_name").(string),
-		IdentityEndpoint:    d.Get("auth_url").(string),
+		AccessKey:           getProviderString(d, profile, "access_key"),
+		SecretKey:           getProviderString(d, profile, "secret_key"),
+		SecurityToken:       d.Get("security_token").(string),
+		CACertFile:          d.Get("cacert_file").(string),
+		ClientCertFile:      d.Get("cert").(string),
+		ClientKeyFile:       d.Get("key").(string),
+		DomainName:          getProviderString(d, profile, "account_name"),
+		IdentityEndpoint:    identityEndpoint,
 		Insecure:            d.Get("insecure").(bool),
 		Password:            d.Get("password").(string),
-		Region:              d.Get("region").(string),
+		Token:               d.Get("token").(string),
+		DomainID:            d.Get("domain_id").(string),
+		Region:              region,
+		TenantID:            d.Get("project_id").(string),
 		TenantName:          project_name,
 		Username:            d.Get("user_name").(string),
+		UserID:              d.Get("user_id").(string),
+		AgencyName:          getProviderString(d, profile, "agency_name"),
+		AgencyDomainName:    getProviderString(d, profile, "agency_domain_name"),
+		DelegatedProject:    delegated_project,
 		TerraformVersion:    terraformVersion,
-		Cloud:               "hc.sbercloud.ru",
+		Cloud:               cloud,
 		MaxRetries:          d.Get("max_retries").(int),
 		EnterpriseProjectID: d.Get("enterprise_project_id").(string),
 		RegionClient:        true,
@@ -307,13 +736,150 @@ func configureProvider(d *schema.ResourceData, terraformVersion string) (interfa
 		RPLock:              new(sync.Mutex),
 	}
 
+	// the project IDs are known in advance, so they are not queried from IAM
+	for k, v := range d.Get("region_project_ids").(map[string]interface{}) {
+		config.RegionProjectIDMap[k] = v.(string)
+	}
+	if projectID, ok := config.RegionProjectIDMap[config.Region]; ok {
+		if config.TenantID == "" {
+			config.TenantID = projectID
+		} else if config.TenantID != projectID {
+			return nil, fmt.Errorf("\"project_id\" %s differs from the project ID %s of the region %s in \"region_project_ids\"",
+				config.TenantID, projectID, config.Region)
+		}
+	}
+	if config.TenantID != "" {
+		config.RegionProjectIDMap[config.Region] = config.TenantID
+	}
+	// the IAM token is scoped to the project of the provider region, which the
+	// service clients enforce for all regions but the one of the provider
+	if config.AccessKey == "" || config.SecretKey == "" {
+		for region := range config.RegionProjectIDMap {
+			if region != config.Region {
+				return nil, fmt.Errorf("the region %s in \"region_project_ids\" requires AK/SK authentication, "+
+					"use a provider alias for region %s or set \"access_key\" and \"secret_key\"", region, region)
+			}
+		}
+	}
+
+	// IAM accepts either the ID or the name of the account, and rejects the requests with both,
+	// so the one set in the provider block is used, and domain_id when neither of them is
+	if config.DomainID != "" && config.DomainName != "" {
+		accountNameSet := isProviderStringSet(d, "account_name", "SBC_ACCOUNT_NAME")
+		domainIDSet := isProviderStringSet(d, "domain_id", "SBC_DOMAIN_ID")
+		switch {
+		case accountNameSet && domainIDSet:
+			return nil, fmt.Errorf("\"account_name\" and \"domain_id\" can not both be specified")
+		case accountNameSet:
+			config.DomainID = ""
+		default:
+			config.DomainName = ""
+		}
+	}
+
+	// the project of the token is scoped by its name, which is only unique in an account
+	if config.Token != "" && config.AgencyName == "" && config.DomainName == "" && config.DomainID == "" &&
+		config.TenantID == "" {
+		return nil, fmt.Errorf("one of \"account_name\", \"domain_id\" or \"project_id\" must be specified " +
+			"when using token authentication")
+	}
+
+	// the AK/SK of the temporary credentials may come from the shared config profile
+	if config.SecurityToken != "" && (config.AccessKey == "" || config.SecretKey == "") {
+		return nil, fmt.Errorf("\"access_key\" and \"secret_key\" must be specified " +
+			"when using \"security_token\"")
+	}
+
+	if config.Password != "" && config.AccessKey == "" && config.Token == "" {
+		if err := checkPasswordAuth(d, &config); err != nil {
+			return nil, err
+		}
+	}
+
+	// The agency is assumed on behalf of the account which owns the AK/SK,
+	// so its name must be known to build the agency token request.
+	if config.AgencyName != "" && config.AccessKey != "" && config.DomainName == "" && config.DomainID == "" {
+		return nil, fmt.Errorf("one of \"account_name\" or \"domain_id\" must be specified " +
+			"when using agency with AK/SK authentication")
+	}
+
+	// get custom endpoints
+	endpoints, err := flattenProviderEndpoints(d)
+	if err != nil {
+		return nil, err
+	}
+	config.Endpoints = endpoints
+
+	policy, err := expandRetryPolicy(d)
+	if err != nil {
+		return nil, err
+	}
+
+	apiLog, err := openAPILog(d)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := config.LoadAndValidate(); err != nil {
 		return nil, err
 	}
 
+	// each attempt of a request is recorded and rate limited
+	limits := expandRateLimitSettings(d)
+	if apiLog != nil || limits != nil {
+		services := newServiceHosts(&config)
+		if apiLog != nil {
+			installAPILog(&config, services, apiLog)
+		}
+		if limits != nil {
+			installRateLimits(&config, services, limits)
+		}
+	}
+	if policy != nil {
+		installRetryPolicy(&config, policy)
+	}
+
 	if config.HwClient != nil && config.HwClient.ProjectID != "" {
 		config.RegionProjectIDMap[config.Region] = config.HwClient.ProjectID
 	}
 
 	return &config, nil
 }
+
+// setClientContext makes the clients of the provider send their requests with ctx.
+func setClientContext(c *config.Config, ctx context.Context) {
+	for _, client := range []*golangsdk.ProviderClient{c.HwClient, c.DomainClient} {
+		if client != nil {
+			client.Context = ctx
+		}
+	}
+}
+
+// checkPasswordAuth checks the identity of the user for username and password authentication.
+// The user is identified either by user_name within the account, or by user_id alone,
+// in which case IAM rejects an account in the request and the project must be given by its ID.
+func checkPasswordAuth(d *schema.ResourceData, c *config.Config) error {
+	if c.UserID == "" {
+		if c.Username == "" {
+			return fmt.Errorf("one of \"user_name\" or \"user_id\" must be specified when using password authentication")
+		}
+		if c.DomainName == "" && c.DomainID == "" {
+			return fmt.Errorf("one of \"account_name\" or \"domain_id\" must be specified with \"user_name\"")
+		}
+		return nil
+	}
+
+	if c.DomainName != "" || c.DomainID != "" {
+		if isProviderStringSet(d, "account_name", "SBC_ACCOUNT_NAME") || isProviderStringSet(d, "domain_id", "SBC_DOMAIN_ID") {
+			return fmt.Errorf("\"account_name\" and \"domain_id\" can not be used with \"user_id\" " +
+				"in password authentication")
+		}
+		// the account of the environment variables or the shared config profile is not sent
+		c.DomainName = ""
+		c.DomainID = ""
+	}
+	if c.TenantID == "" && c.AgencyName == "" {
+		return fmt.Errorf("\"project_id\" must be specified with \"user_id\" in password authentication")
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package sbercloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderServer returns the gRPC server of the SberCloud provider.
+func ProviderServer() tfprotov5.ProviderServer {
+	return newProviderServer(Provider())
+}
+
+// providerServer returns the warnings of the quota checks with the plan, as the
+// CustomizeDiff of the SDK only supports errors. The quotas taken by the new resources
+// planned so far are added up, so that each resource is checked together with the
+// ones planned before it.
+type providerServer struct {
+	tfprotov5.ProviderServer
+
+	plannedQuotas *plannedQuotas
+}
+
+func newProviderServer(provider *schema.Provider) *providerServer {
+	return &providerServer{
+		ProviderServer: schema.NewGRPCProviderServer(provider),
+	}
+}
+
+func (s *providerServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (
+	*tfprotov5.ConfigureProviderResponse, error) {
+	s.plannedQuotas = newPlannedQuotas()
+	return s.ProviderServer.ConfigureProvider(ctx, req)
+}
+
+func (s *providerServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (
+	*tfprotov5.PlanResourceChangeResponse, error) {
+	ctx, warnings := withPlanWarnings(ctx, s.plannedQuotas)
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	if !hasErrorDiagnostics(resp.Diagnostics) {
+		s.plannedQuotas.add(warnings.requests())
+	}
+	for _, d := range warnings.diagnostics() {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  d.Summary,
+			Detail:   d.Detail,
+		})
+	}
+	return resp, nil
+}
+
+func hasErrorDiagnostics(diags []*tfprotov5.Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
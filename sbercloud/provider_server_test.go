@@ -0,0 +1,39 @@
+package sbercloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+// testResourceValue returns an object of the type with the attributes, the other attributes are null.
+func testResourceValue(ty cty.Type, attrs map[string]cty.Value) cty.Value {
+	values := make(map[string]cty.Value)
+	for name, attrType := range ty.AttributeTypes() {
+		if v, ok := attrs[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = cty.NullVal(attrType)
+		}
+	}
+	return cty.ObjectVal(values)
+}
+
+func mustEncodeDynamicValue(t *testing.T, v cty.Value, ty cty.Type) *tfprotov5.DynamicValue {
+	b, err := msgpack.Marshal(v, ty)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return &tfprotov5.DynamicValue{MsgPack: b}
+}
+
+func expectNoDiagnostics(t *testing.T, diags []*tfprotov5.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+}
@@ -1,12 +1,31 @@
 package sbercloud
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/pathorcontents"
 )
 
@@ -14,12 +33,18 @@ var (
 	SBC_ACCESS_KEY                 = os.Getenv("SBC_ACCESS_KEY")
 	SBC_ACCOUNT_NAME               = os.Getenv("SBC_ACCOUNT_NAME")
 	SBC_ADMIN                      = os.Getenv("SBC_ADMIN")
+	SBC_CBR_BACKUP_ID              = os.Getenv("SBC_CBR_BACKUP_ID")
+	SBC_CCE_AUTOSCALER_VERSION     = os.Getenv("SBC_CCE_AUTOSCALER_VERSION")
+	SBC_CCE_METRICS_SERVER_VERSION = os.Getenv("SBC_CCE_METRICS_SERVER_VERSION")
 	SBC_DOMAIN_ID                  = os.Getenv("SBC_DOMAIN_ID")
 	SBC_DOMAIN_NAME                = os.Getenv("SBC_DOMAIN_NAME")
 	SBC_ENTERPRISE_PROJECT_ID_TEST = os.Getenv("SBC_ENTERPRISE_PROJECT_ID_TEST")
 	SBC_PROJECT_ID                 = os.Getenv("SBC_PROJECT_ID")
 	SBC_REGION_NAME                = os.Getenv("SBC_REGION_NAME")
 	SBC_SECRET_KEY                 = os.Getenv("SBC_SECRET_KEY")
+	SBC_SWR_REPOSITORY             = os.Getenv("SBC_SWR_REPOSITORY")
+	SBC_SWR_SHARING_ACCOUNT        = os.Getenv("SBC_SWR_SHARING_ACCOUNT")
+	SBC_WAF_ENABLE_FLAG            = os.Getenv("SBC_WAF_ENABLE_FLAG")
 )
 
 var testAccProviders map[string]*schema.Provider
@@ -54,6 +79,36 @@ func testAccPreCheckEpsID(t *testing.T) {
 	}
 }
 
+func testAccPreCheckCBRBackup(t *testing.T) {
+	if SBC_CBR_BACKUP_ID == "" {
+		t.Skip("SBC_CBR_BACKUP_ID must be set for CBR backup acceptance tests")
+	}
+}
+
+func testAccPreCheckCCEAddon(t *testing.T) {
+	if SBC_CCE_AUTOSCALER_VERSION == "" || SBC_CCE_METRICS_SERVER_VERSION == "" {
+		t.Skip("SBC_CCE_AUTOSCALER_VERSION and SBC_CCE_METRICS_SERVER_VERSION must be set for CCE add-on acceptance tests")
+	}
+}
+
+func testAccPreCheckSWRRepository(t *testing.T) {
+	if SBC_SWR_REPOSITORY == "" {
+		t.Skip("SBC_SWR_REPOSITORY must be set for SWR image tags acceptance tests")
+	}
+}
+
+func testAccPreCheckSWRSharingAccount(t *testing.T) {
+	if SBC_SWR_SHARING_ACCOUNT == "" {
+		t.Skip("SBC_SWR_SHARING_ACCOUNT must be set for SWR repository sharing acceptance tests")
+	}
+}
+
+func testAccPreCheckWaf(t *testing.T) {
+	if SBC_WAF_ENABLE_FLAG == "" {
+		t.Skip("SBC_WAF_ENABLE_FLAG must be set for WAF acceptance tests")
+	}
+}
+
 func testAccPreCheckOBS(t *testing.T) {
 	if SBC_ACCESS_KEY == "" || SBC_SECRET_KEY == "" {
 		t.Skip("SBC_ACCESS_KEY and SBC_SECRET_KEY must be set for OBS acceptance tests")
@@ -70,6 +125,926 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestProvider_securityTokenRequiresAKSK(t *testing.T) {
+	setTestHomeDir(t)
+	t.Setenv("SBC_ACCESS_KEY", "")
+	t.Setenv("SBC_SECRET_KEY", "")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":         "ru-moscow-1",
+		"security_token": "temporary-token",
+	})
+	_, err := configureProvider(d, "0.12+compatible")
+	if err == nil || !strings.Contains(err.Error(), `when using "security_token"`) {
+		t.Fatalf("expected an error when security_token is set without access_key and secret_key, got %v", err)
+	}
+}
+
+func TestProvider_securityToken(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":         "ru-moscow-1",
+		"auth_url":       iam.URL + "/v3",
+		"access_key":     "temporary-ak",
+		"secret_key":     "temporary-sk",
+		"security_token": "temporary-token",
+		"max_retries":    0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	conf := meta.(*config.Config)
+	if conf.SecurityToken != "temporary-token" {
+		t.Fatalf("expected the security token to be passed through, got %q", conf.SecurityToken)
+	}
+	if conf.HwClient.ProjectID != testIAMProjectID {
+		t.Fatalf("expected project ID %s, got %s", testIAMProjectID, conf.HwClient.ProjectID)
+	}
+	if got := iam.header("X-Security-Token"); got != "temporary-token" {
+		t.Fatalf("expected IAM requests to carry the security token, got %q", got)
+	}
+}
+
+func TestProvider_endpointsUnknownService(t *testing.T) {
+	raw := map[string]interface{}{
+		"region": "ru-moscow-1",
+		"endpoints": map[string]interface{}{
+			"ecs":     "ecs.example.com",
+			"unknown": "unknown.example.com",
+		},
+	}
+
+	diags := Provider().Validate(terraform.NewResourceConfigRaw(raw))
+	var found bool
+	for _, d := range diags {
+		if strings.Contains(d.Summary+d.Detail, `unknown service "unknown"`) {
+			found = true
+			if !strings.Contains(d.Summary+d.Detail, "ecs, ") {
+				t.Fatalf("expected the error to list the valid services: %s", d.Summary)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("expected an unknown service error, got %v", diags)
+	}
+
+	delete(raw["endpoints"].(map[string]interface{}), "unknown")
+	if hasProviderConfigError(raw, "endpoints") {
+		t.Fatal("unexpected endpoints error for a known service")
+	}
+}
+
+func TestProvider_endpoints(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "my-ak",
+		"secret_key":  "my-sk",
+		"max_retries": 0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+			"ecs": " ecs.example.com ",
+			"vpc": "http://127.0.0.1:8080/vpc",
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	conf := meta.(*config.Config)
+	expected := map[string]string{
+		"identity":       iam.URL + "/",
+		"ecs":            "https://ecs.example.com/",
+		"ecsv21":         "https://ecs.example.com/",
+		"networkv2":      "http://127.0.0.1:8080/vpc/",
+		"security_group": "http://127.0.0.1:8080/vpc/",
+	}
+	for k, v := range expected {
+		if conf.Endpoints[k] != v {
+			t.Fatalf("expected endpoint %s to be %s, got %s", k, v, conf.Endpoints[k])
+		}
+	}
+
+	// the domain ID is resolved through the custom IAM endpoint
+	if conf.DomainID != testIAMDomainID {
+		t.Fatalf("expected domain ID %s, got %q", testIAMDomainID, conf.DomainID)
+	}
+
+	client, err := conf.NewServiceClient("ecs", "ru-moscow-1")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.Endpoint != "https://ecs.example.com/" {
+		t.Fatalf("unexpected ECS endpoint: %s", client.Endpoint)
+	}
+}
+
+func TestProvider_cloud(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-private-1",
+		"cloud":       "cloud.example.com",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "my-ak",
+		"secret_key":  "my-sk",
+		"max_retries": 0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	client, err := meta.(*config.Config).NewServiceClient("vpc", "ru-private-1")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if client.Endpoint != "https://vpc.ru-private-1.cloud.example.com/" {
+		t.Fatalf("unexpected VPC endpoint: %s", client.Endpoint)
+	}
+}
+
+func TestProvider_clientCertRequiresKey(t *testing.T) {
+	t.Setenv("SBC_KEY", "")
+
+	raw := map[string]interface{}{
+		"region": "ru-moscow-1",
+		"cert":   "client.pem",
+	}
+	if !hasProviderConfigError(raw, "cert") {
+		t.Fatal("expected an error when cert is set without key")
+	}
+}
+
+func TestProvider_tls(t *testing.T) {
+	certs := newTestCertificates(t)
+	iam := startTestIAMServer(t, certs.serverTLSConfig)
+
+	// the CA is passed by path, the client certificate and key as contents
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(caFile, certs.caPEM, 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	cases := map[string]struct {
+		raw         map[string]interface{}
+		expectedErr string
+	}{
+		"mutual TLS": {
+			raw: map[string]interface{}{
+				"cacert_file": caFile,
+				"cert":        string(certs.clientCertPEM),
+				"key":         string(certs.clientKeyPEM),
+			},
+		},
+		"unknown CA": {
+			raw: map[string]interface{}{
+				"cert": string(certs.clientCertPEM),
+				"key":  string(certs.clientKeyPEM),
+			},
+			expectedErr: "certificate",
+		},
+		"missing client certificate": {
+			raw: map[string]interface{}{
+				"cacert_file": string(certs.caPEM),
+			},
+			expectedErr: "certificate",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"region":      "ru-moscow-1",
+				"auth_url":    iam.URL + "/v3",
+				"access_key":  "my-ak",
+				"secret_key":  "my-sk",
+				"max_retries": 0,
+				"endpoints": map[string]interface{}{
+					"iam": iam.URL,
+				},
+			}
+			for k, v := range tc.raw {
+				raw[k] = v
+			}
+
+			meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, raw), "0.12+compatible")
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected an error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if projectID := meta.(*config.Config).HwClient.ProjectID; projectID != testIAMProjectID {
+				t.Fatalf("expected project ID %s, got %s", testIAMProjectID, projectID)
+			}
+		})
+	}
+}
+
+// testCertificates holds a generated CA together with the server and client
+// certificates issued by it.
+type testCertificates struct {
+	caPEM           []byte
+	clientCertPEM   []byte
+	clientKeyPEM    []byte
+	serverTLSConfig *tls.Config
+}
+
+func newTestCertificates(t *testing.T) *testCertificates {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	issue := func(serial int64, template *x509.Certificate) ([]byte, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		template.SerialNumber = big.NewInt(serial)
+		template.NotBefore = caTemplate.NotBefore
+		template.NotAfter = caTemplate.NotAfter
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+
+	serverCertPEM, serverKeyPEM := issue(2, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	clientCertPEM, clientKeyPEM := issue(3, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "terraform"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+
+	return &testCertificates{
+		caPEM:         pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		clientCertPEM: clientCertPEM,
+		clientKeyPEM:  clientKeyPEM,
+		serverTLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{serverCert},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    pool,
+		},
+	}
+}
+
+// hasProviderConfigError validates the raw provider configuration and reports
+// whether any of the errors refers to the given argument.
+func hasProviderConfigError(raw map[string]interface{}, key string) bool {
+	diags := Provider().Validate(terraform.NewResourceConfigRaw(raw))
+	for _, d := range diags {
+		if d.Severity == diag.Error && strings.Contains(d.Summary+d.Detail, fmt.Sprintf("%q", key)) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestProvider_tokenConflicts(t *testing.T) {
+	for _, key := range []string{"password", "access_key", "secret_key"} {
+		raw := map[string]interface{}{
+			"region": "ru-moscow-1",
+			"token":  "my-token",
+			key:      "value",
+		}
+		if !hasProviderConfigError(raw, "token") {
+			t.Fatalf("expected token to conflict with %s", key)
+		}
+	}
+}
+
+func TestProvider_token(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":       "ru-moscow-1",
+		"auth_url":     iam.URL + "/v3",
+		"token":        "my-token",
+		"account_name": testIAMDomainName,
+		"max_retries":  0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	conf := meta.(*config.Config)
+	if conf.HwClient.ProjectID != testIAMProjectID {
+		t.Fatalf("expected project ID %s, got %s", testIAMProjectID, conf.HwClient.ProjectID)
+	}
+	if got := iam.lastAuthToken(); got != "my-token" {
+		t.Fatalf("expected the token to be sent to IAM, got %q", got)
+	}
+}
+
+func TestProvider_agency(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":             "ru-moscow-1",
+		"auth_url":           iam.URL + "/v3",
+		"access_key":         "automation-ak",
+		"secret_key":         "automation-sk",
+		"account_name":       testIAMDomainName,
+		"agency_name":        "tenant_admin",
+		"agency_domain_name": "tenant-account",
+		"max_retries":        0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	conf := meta.(*config.Config)
+	if conf.DelegatedProject != "ru-moscow-1" {
+		t.Fatalf("expected delegated_project to default to the region, got %q", conf.DelegatedProject)
+	}
+	if conf.HwClient.ProjectID != testIAMAgencyProjectID {
+		t.Fatalf("expected the delegated project ID %s, got %s", testIAMAgencyProjectID, conf.HwClient.ProjectID)
+	}
+
+	assumeRole := iam.assumeRole()
+	if assumeRole["xrole_name"] != "tenant_admin" || assumeRole["domain_name"] != "tenant-account" {
+		t.Fatalf("unexpected assume_role request: %v", assumeRole)
+	}
+}
+
+func TestProvider_agencyRequiresAccountName(t *testing.T) {
+	t.Setenv("SBC_ACCOUNT_NAME", "")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":             "ru-moscow-1",
+		"access_key":         "automation-ak",
+		"secret_key":         "automation-sk",
+		"agency_name":        "tenant_admin",
+		"agency_domain_name": "tenant-account",
+	})
+
+	_, err := configureProvider(d, "0.12+compatible")
+	if err == nil || !strings.Contains(err.Error(), "account_name") {
+		t.Fatalf("expected an account_name error, got %v", err)
+	}
+}
+
+func TestProvider_projectAndDomainID(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "automation-ak",
+		"secret_key":  "automation-sk",
+		"project_id":  testIAMProjectID,
+		"domain_id":   testIAMDomainID,
+		"max_retries": 0,
+		"region_project_ids": map[string]interface{}{
+			"ru-moscow-2": testIAMAgencyProjectID,
+		},
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, path := range []string{"/v3/projects", "/v3/auth/domains"} {
+		if iam.requested(path) {
+			t.Fatalf("expected no request to %s when the IDs are given", path)
+		}
+	}
+
+	conf := meta.(*config.Config)
+	if conf.DomainID != testIAMDomainID {
+		t.Fatalf("expected domain ID %s, got %s", testIAMDomainID, conf.DomainID)
+	}
+	if conf.HwClient.ProjectID != testIAMProjectID {
+		t.Fatalf("expected project ID %s, got %s", testIAMProjectID, conf.HwClient.ProjectID)
+	}
+	expected := map[string]string{
+		"ru-moscow-1": testIAMProjectID,
+		"ru-moscow-2": testIAMAgencyProjectID,
+	}
+	for region, projectID := range expected {
+		if got := conf.RegionProjectIDMap[region]; got != projectID {
+			t.Fatalf("expected the project ID %s for %s, got %q", projectID, region, got)
+		}
+	}
+}
+
+func TestProvider_regionProjectIDs(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "automation-ak",
+		"secret_key":  "automation-sk",
+		"max_retries": 0,
+		"region_project_ids": map[string]interface{}{
+			"ru-moscow-1": testIAMProjectID,
+		},
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if iam.requested("/v3/projects") {
+		t.Fatal("expected the project ID of the provider region to be taken from region_project_ids")
+	}
+	if conf := meta.(*config.Config); conf.TenantID != testIAMProjectID {
+		t.Fatalf("expected project ID %s, got %s", testIAMProjectID, conf.TenantID)
+	}
+}
+
+func TestProvider_userID(t *testing.T) {
+	// the account of the environment variable is not sent with the user ID
+	t.Setenv("SBC_ACCOUNT_NAME", testIAMDomainName)
+	iam := newTestIAMServer(t)
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"user_id":     "b3e2ac1c0d624ee7a8e3f1d97f1c0a35",
+		"password":    "my-password",
+		"project_id":  testIAMProjectID,
+		"max_retries": 0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if user := iam.lastPasswordUser(); user["id"] != "b3e2ac1c0d624ee7a8e3f1d97f1c0a35" || user["name"] != nil || user["domain"] != nil {
+		t.Fatalf("expected to login with the user ID, got %v", user)
+	}
+	if iam.requested("/v3/users") {
+		t.Fatal("expected no request to /v3/users when user_id is given")
+	}
+	if conf := meta.(*config.Config); conf.UserID != "b3e2ac1c0d624ee7a8e3f1d97f1c0a35" {
+		t.Fatalf("expected user ID to be kept, got %q", conf.UserID)
+	}
+}
+
+func TestProvider_passwordIdentity(t *testing.T) {
+	t.Setenv("SBC_ACCOUNT_NAME", "")
+	t.Setenv("SBC_PROJECT_ID", "")
+	t.Setenv("SBC_DOMAIN_ID", "")
+
+	cases := map[string]struct {
+		raw      map[string]interface{}
+		expected string
+	}{
+		"user id with account name": {
+			raw: map[string]interface{}{
+				"user_id":      "b3e2ac1c0d624ee7a8e3f1d97f1c0a35",
+				"account_name": testIAMDomainName,
+				"project_id":   testIAMProjectID,
+			},
+			expected: "can not be used with \"user_id\"",
+		},
+		"user id without project id": {
+			raw: map[string]interface{}{
+				"user_id": "b3e2ac1c0d624ee7a8e3f1d97f1c0a35",
+			},
+			expected: "\"project_id\" must be specified",
+		},
+		"user name without account": {
+			raw: map[string]interface{}{
+				"user_name": "automation",
+			},
+			expected: "one of \"account_name\" or \"domain_id\"",
+		},
+		"no user": {
+			raw: map[string]interface{}{
+				"account_name": testIAMDomainName,
+			},
+			expected: "one of \"user_name\" or \"user_id\"",
+		},
+		"conflicting project ids": {
+			raw: map[string]interface{}{
+				"user_name":    "automation",
+				"account_name": testIAMDomainName,
+				"project_id":   testIAMProjectID,
+				"region_project_ids": map[string]interface{}{
+					"ru-moscow-1": testIAMAgencyProjectID,
+				},
+			},
+			expected: "differs from the project ID",
+		},
+		"project id of another region": {
+			raw: map[string]interface{}{
+				"user_name":    "automation",
+				"account_name": testIAMDomainName,
+				"region_project_ids": map[string]interface{}{
+					"ru-moscow-2": testIAMAgencyProjectID,
+				},
+			},
+			expected: "use a provider alias for region ru-moscow-2",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.raw["region"] = "ru-moscow-1"
+			tc.raw["password"] = "my-password"
+			d := schema.TestResourceDataRaw(t, Provider().Schema, tc.raw)
+
+			_, err := configureProvider(d, "0.12+compatible")
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Fatalf("expected an error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestProvider_userIDConflictsWithUserName(t *testing.T) {
+	raw := map[string]interface{}{
+		"region":       "ru-moscow-1",
+		"user_id":      "b3e2ac1c0d624ee7a8e3f1d97f1c0a35",
+		"user_name":    "automation",
+		"password":     "my-password",
+		"account_name": testIAMDomainName,
+	}
+	if !hasProviderConfigError(raw, "user_id") {
+		t.Fatal("expected user_id to conflict with user_name")
+	}
+}
+
+func TestProvider_accountNameConflictsWithDomainID(t *testing.T) {
+	t.Setenv("SBC_ACCOUNT_NAME", "")
+	t.Setenv("SBC_DOMAIN_ID", "")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":       "ru-moscow-1",
+		"access_key":   "automation-ak",
+		"secret_key":   "automation-sk",
+		"account_name": testIAMDomainName,
+		"domain_id":    testIAMDomainID,
+	})
+
+	_, err := configureProvider(d, "0.12+compatible")
+	if err == nil || !strings.Contains(err.Error(), "can not both be specified") {
+		t.Fatalf("expected account_name to conflict with domain_id, got %v", err)
+	}
+}
+
+func TestProvider_accountNameFromEnvWithDomainID(t *testing.T) {
+	t.Setenv("SBC_ACCOUNT_NAME", testIAMDomainName)
+	iam := newTestIAMServer(t)
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "automation-ak",
+		"secret_key":  "automation-sk",
+		"project_id":  testIAMProjectID,
+		"domain_id":   testIAMDomainID,
+		"max_retries": 0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if conf := meta.(*config.Config); conf.DomainID != testIAMDomainID || conf.DomainName != "" {
+		t.Fatalf("expected only the domain ID to be used, got %q and %q", conf.DomainID, conf.DomainName)
+	}
+}
+
+const (
+	testIAMProjectID       = "0b1bc6ea4c80d2f32fd8c00e2a7b1d7d"
+	testIAMAgencyProjectID = "5f3e7c1d9b8a4e6f8c2d1a0b9e8f7c6d"
+	testIAMSubProjectID    = "c7a9e3b5d1f04a2c8e6b4d2f0a8c6e4b"
+	testIAMDomainID        = "a6b4f9fd3bd1430d9e34e2fd3d4a0e72"
+	testIAMDomainName      = "automation-account"
+	testIAMUserID          = "d3f8a1c6e0b94b2e8f6a7c5d4b3a2910"
+	testIAMUserName        = "automation-user"
+)
+
+// testIAMServer is a local stand-in for the IAM endpoints used during
+// provider configuration. It remembers the headers of the last request.
+type testIAMServer struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	paths          []string
+	headers        http.Header
+	assumeRoleBody map[string]interface{}
+	authToken      string
+	passwordUser   map[string]interface{}
+}
+
+func newTestIAMServer(t *testing.T) *testIAMServer {
+	return startTestIAMServer(t, nil)
+}
+
+// startTestIAMServer starts the IAM stand-in, it serves HTTPS when tlsConfig is not nil.
+func startTestIAMServer(t *testing.T, tlsConfig *tls.Config) *testIAMServer {
+	s := &testIAMServer{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/projects", func(w http.ResponseWriter, r *http.Request) {
+		s.record(r)
+		writeTestJSON(w, map[string]interface{}{
+			"projects": []map[string]interface{}{
+				{
+					"id":        testIAMProjectID,
+					"name":      r.URL.Query().Get("name"),
+					"domain_id": testIAMDomainID,
+					"enabled":   true,
+				},
+			},
+			"links": map[string]interface{}{},
+		})
+	})
+
+	mux.HandleFunc("/v3/auth/domains", func(w http.ResponseWriter, r *http.Request) {
+		s.record(r)
+		writeTestJSON(w, map[string]interface{}{
+			"domains": []map[string]interface{}{
+				{
+					"id":      testIAMDomainID,
+					"name":    testIAMDomainName,
+					"enabled": true,
+				},
+			},
+			"links": map[string]interface{}{},
+		})
+	})
+	mux.HandleFunc("/v3/auth/projects", func(w http.ResponseWriter, r *http.Request) {
+		s.record(r)
+		writeTestJSON(w, map[string]interface{}{
+			"projects": []map[string]interface{}{
+				{
+					"id":        testIAMProjectID,
+					"name":      "ru-moscow-1",
+					"domain_id": testIAMDomainID,
+					"enabled":   true,
+				},
+				{
+					"id":        testIAMAgencyProjectID,
+					"name":      "ru-moscow-2",
+					"domain_id": testIAMDomainID,
+					"enabled":   true,
+				},
+				{
+					"id":        testIAMSubProjectID,
+					"name":      "ru-moscow-1_dev",
+					"domain_id": testIAMDomainID,
+					"enabled":   true,
+				},
+				{
+					"id":        "8e2f6a0c4b1d4e7f9a3c5b2d1e0f9a8b",
+					"name":      "MOS",
+					"domain_id": testIAMDomainID,
+					"enabled":   true,
+				},
+			},
+			"links": map[string]interface{}{},
+		})
+	})
+	mux.HandleFunc("/v3.0/OS-CREDENTIAL/credentials/", func(w http.ResponseWriter, r *http.Request) {
+		s.record(r)
+		writeTestJSON(w, map[string]interface{}{
+			"credential": map[string]interface{}{
+				"access":  strings.TrimPrefix(r.URL.Path, "/v3.0/OS-CREDENTIAL/credentials/"),
+				"user_id": testIAMUserID,
+				"status":  "active",
+			},
+		})
+	})
+	mux.HandleFunc("/v3.0/OS-USER/users/", func(w http.ResponseWriter, r *http.Request) {
+		s.record(r)
+		if strings.TrimPrefix(r.URL.Path, "/v3.0/OS-USER/users/") != testIAMUserID {
+			http.NotFound(w, r)
+			return
+		}
+		writeTestJSON(w, map[string]interface{}{
+			"user": map[string]interface{}{
+				"id":        testIAMUserID,
+				"name":      testIAMUserName,
+				"domain_id": testIAMDomainID,
+				"enabled":   true,
+			},
+		})
+	})
+	mux.HandleFunc("/v3/auth/catalog", func(w http.ResponseWriter, r *http.Request) {
+		s.record(r)
+		writeTestJSON(w, map[string]interface{}{
+			"catalog": []interface{}{},
+			"links":   map[string]interface{}{},
+		})
+	})
+	mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		s.record(r)
+
+		// validates the token of X-Subject-Token
+		if r.Method == http.MethodGet {
+			writeTestJSON(w, map[string]interface{}{
+				"token": map[string]interface{}{
+					"expires_at": "2099-01-01T00:00:00.000000Z",
+					"user": map[string]interface{}{
+						"id":   testIAMUserID,
+						"name": testIAMUserName,
+						"domain": map[string]interface{}{
+							"id":   testIAMDomainID,
+							"name": testIAMDomainName,
+						},
+					},
+				},
+			})
+			return
+		}
+
+		var body struct {
+			Auth struct {
+				Identity struct {
+					AssumeRole map[string]interface{} `json:"assume_role"`
+					Token      struct {
+						ID string `json:"id"`
+					} `json:"token"`
+					Password struct {
+						User map[string]interface{} `json:"user"`
+					} `json:"password"`
+				} `json:"identity"`
+			} `json:"auth"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.assumeRoleBody = body.Auth.Identity.AssumeRole
+		s.authToken = body.Auth.Identity.Token.ID
+		s.passwordUser = body.Auth.Identity.Password.User
+		s.mu.Unlock()
+
+		projectID := testIAMProjectID
+		if body.Auth.Identity.AssumeRole != nil {
+			projectID = testIAMAgencyProjectID
+		}
+
+		w.Header().Set("X-Subject-Token", "issued-token")
+		w.WriteHeader(http.StatusCreated)
+		writeTestJSON(w, map[string]interface{}{
+			"token": map[string]interface{}{
+				"expires_at": "2099-01-01T00:00:00.000000Z",
+				"project": map[string]interface{}{
+					"id":   projectID,
+					"name": "ru-moscow-1",
+					"domain": map[string]interface{}{
+						"id": testIAMDomainID,
+					},
+				},
+				"catalog": []interface{}{},
+			},
+		})
+	})
+
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.paths = append(s.paths, r.URL.Path)
+		s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	if tlsConfig != nil {
+		s.Server.TLS = tlsConfig
+		s.Server.StartTLS()
+	} else {
+		s.Server.Start()
+	}
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testIAMServer) record(r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.headers = r.Header.Clone()
+}
+
+func (s *testIAMServer) header(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.headers.Get(key)
+}
+
+func (s *testIAMServer) assumeRole() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.assumeRoleBody
+}
+
+// lastAuthToken returns the token which was used to issue the last token.
+func (s *testIAMServer) lastAuthToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.authToken
+}
+
+// requested reports whether a request was sent to the path.
+func (s *testIAMServer) requested(path string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range s.paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// lastPasswordUser returns the user of the last password authentication.
+func (s *testIAMServer) lastPasswordUser() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.passwordUser
+}
+
+func writeTestJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
 func envVarContents(varName string) (string, error) {
 	contents, _, err := pathorcontents.Read(os.Getenv(varName))
 	if err != nil {
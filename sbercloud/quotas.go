@@ -0,0 +1,468 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/blockstorage/extensions/quotasets"
+	"github.com/chnsz/golangsdk/openstack/compute/v2/extensions/limits"
+	"github.com/chnsz/golangsdk/openstack/compute/v2/flavors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// the services whose quotas are queried
+const (
+	quotaServiceCompute = "compute"
+	quotaServiceNetwork = "network"
+	quotaServiceEVS     = "evs"
+)
+
+var quotaServices = []string{quotaServiceCompute, quotaServiceEVS, quotaServiceNetwork}
+
+// quotaUsage is the usage of a quota of the project, a limit of -1 means unlimited.
+type quotaUsage struct {
+	Service string
+	Type    string
+	Used    int
+	Limit   int
+}
+
+// listQuotas returns the quotas of the services in the region, ordered by service and type.
+func listQuotas(c *config.Config, region string, services []string) ([]quotaUsage, error) {
+	var all []quotaUsage
+	for _, service := range services {
+		quotas, err := listServiceQuotas(c, region, service)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, quotas...)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].Service != all[j].Service {
+			return all[i].Service < all[j].Service
+		}
+		return all[i].Type < all[j].Type
+	})
+	return all, nil
+}
+
+func listServiceQuotas(c *config.Config, region, service string) ([]quotaUsage, error) {
+	var quotas []quotaUsage
+	var err error
+	switch service {
+	case quotaServiceCompute:
+		quotas, err = listComputeQuotas(c, region)
+	case quotaServiceNetwork:
+		quotas, err = listNetworkQuotas(c, region)
+	case quotaServiceEVS:
+		quotas, err = listEVSQuotas(c, region)
+	default:
+		err = fmt.Errorf("unsupported service")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error querying the %s quotas: %s", service, err)
+	}
+	return quotas, nil
+}
+
+func listComputeQuotas(c *config.Config, region string) ([]quotaUsage, error) {
+	computeClient, err := c.ComputeV2Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating compute client: %s", err)
+	}
+
+	l, err := limits.Get(computeClient, nil).Extract()
+	if err != nil {
+		return nil, err
+	}
+	if l == nil {
+		return nil, fmt.Errorf("no limits are returned")
+	}
+
+	abs := l.Absolute
+	return []quotaUsage{
+		{Service: quotaServiceCompute, Type: "instances", Used: abs.TotalInstancesUsed, Limit: abs.MaxTotalInstances},
+		{Service: quotaServiceCompute, Type: "cores", Used: abs.TotalCoresUsed, Limit: abs.MaxTotalCores},
+		{Service: quotaServiceCompute, Type: "ram", Used: abs.TotalRAMUsed, Limit: abs.MaxTotalRAMSize},
+		{Service: quotaServiceCompute, Type: "server_groups", Used: abs.TotalServerGroupsUsed, Limit: abs.MaxServerGroups},
+	}, nil
+}
+
+func listNetworkQuotas(c *config.Config, region string) ([]quotaUsage, error) {
+	vpcClient, err := c.NetworkingV1Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating VPC client: %s", err)
+	}
+
+	var resp struct {
+		Quotas struct {
+			Resources []struct {
+				Type  string `json:"type"`
+				Used  int    `json:"used"`
+				Quota int    `json:"quota"`
+			} `json:"resources"`
+		} `json:"quotas"`
+	}
+	_, err = vpcClient.Get(vpcClient.ServiceURL(vpcClient.ProjectID, "quotas"), &resp, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	quotas := make([]quotaUsage, 0, len(resp.Quotas.Resources))
+	for _, r := range resp.Quotas.Resources {
+		quotas = append(quotas, quotaUsage{Service: quotaServiceNetwork, Type: r.Type, Used: r.Used, Limit: r.Quota})
+	}
+	return quotas, nil
+}
+
+func listEVSQuotas(c *config.Config, region string) ([]quotaUsage, error) {
+	blockStorageClient, err := c.BlockStorageV2Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating EVS client: %s", err)
+	}
+
+	q, err := quotasets.GetUsage(blockStorageClient, blockStorageClient.ProjectID).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	usages := map[string]quotasets.QuotaUsage{
+		"volumes":          q.Volumes,
+		"gigabytes":        q.Gigabytes,
+		"snapshots":        q.Snapshots,
+		"backups":          q.Backups,
+		"backup_gigabytes": q.BackupGigabytes,
+	}
+	quotas := make([]quotaUsage, 0, len(usages))
+	for quotaType, usage := range usages {
+		quotas = append(quotas, quotaUsage{Service: quotaServiceEVS, Type: quotaType, Used: usage.InUse, Limit: usage.Limit})
+	}
+	return quotas, nil
+}
+
+// quotaRequest is the amount of a quota which is taken by a new resource.
+type quotaRequest struct {
+	Service string
+	Type    string
+	Amount  int
+}
+
+// quotaCheck returns the quotas taken by a new resource, the flavors are queried with the planned quotas.
+type quotaCheck func(d *schema.ResourceDiff, planned *plannedQuotas, c *config.Config, region string) ([]quotaRequest, error)
+
+// quotaChecks returns the quotas taken by the new resources of the types, the amounts
+// which are unknown at plan time are not checked.
+var quotaChecks = map[string]quotaCheck{
+	"sbercloud_compute_instance": func(d *schema.ResourceDiff, planned *plannedQuotas, c *config.Config,
+		region string) ([]quotaRequest, error) {
+		requests := []quotaRequest{{Service: quotaServiceCompute, Type: "instances", Amount: 1}}
+		flavor, err := computeInstanceFlavor(d, planned, c, region)
+		if err != nil {
+			return nil, err
+		}
+		if flavor != nil {
+			requests = append(requests,
+				quotaRequest{Service: quotaServiceCompute, Type: "cores", Amount: flavor.VCPUs},
+				quotaRequest{Service: quotaServiceCompute, Type: "ram", Amount: flavor.RAM})
+		}
+		return requests, nil
+	},
+	"sbercloud_evs_volume": func(d *schema.ResourceDiff, _ *plannedQuotas, _ *config.Config, _ string) ([]quotaRequest, error) {
+		requests := []quotaRequest{{Service: quotaServiceEVS, Type: "volumes", Amount: 1}}
+		if d.NewValueKnown("size") {
+			requests = append(requests, quotaRequest{Service: quotaServiceEVS, Type: "gigabytes", Amount: d.Get("size").(int)})
+		}
+		return requests, nil
+	},
+	"sbercloud_vpc": func(_ *schema.ResourceDiff, _ *plannedQuotas, _ *config.Config, _ string) ([]quotaRequest, error) {
+		return []quotaRequest{{Service: quotaServiceNetwork, Type: "vpc", Amount: 1}}, nil
+	},
+	"sbercloud_vpc_eip": func(_ *schema.ResourceDiff, _ *plannedQuotas, _ *config.Config, _ string) ([]quotaRequest, error) {
+		return []quotaRequest{{Service: quotaServiceNetwork, Type: "publicIp", Amount: 1}}, nil
+	},
+}
+
+// computeInstanceFlavor returns the flavor of a new compute instance, or nil if the flavor
+// is not known at plan time.
+func computeInstanceFlavor(d *schema.ResourceDiff, planned *plannedQuotas, c *config.Config,
+	region string) (*flavors.Flavor, error) {
+	var flavorID, flavorName string
+	if d.NewValueKnown("flavor_id") {
+		flavorID = d.Get("flavor_id").(string)
+	}
+	if d.NewValueKnown("flavor_name") {
+		flavorName = d.Get("flavor_name").(string)
+	}
+	if flavorID == "" && flavorName == "" {
+		return nil, nil
+	}
+	return planned.flavor(c, region, flavorID, flavorName)
+}
+
+func getComputeFlavor(c *config.Config, region, flavorID, flavorName string) (*flavors.Flavor, error) {
+	computeClient, err := c.ComputeV2Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating compute client: %s", err)
+	}
+	if flavorID == "" {
+		flavorID, err = flavors.IDFromName(computeClient, flavorName)
+		if err != nil {
+			return nil, fmt.Errorf("error querying the flavor %s: %s", flavorName, err)
+		}
+	}
+
+	flavor, err := flavors.Get(computeClient, flavorID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("error querying the flavor %s: %s", flavorID, err)
+	}
+	return flavor, nil
+}
+
+// wrapResourceQuotaCheck warns at plan time when the new resources of the plan would exceed
+// the quotas of the project. The warnings are only collected with the check_quotas of the provider.
+func wrapResourceQuotaCheck(r *schema.Resource, check quotaCheck, settings *providerSettings) {
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		warnings := planWarningsFromContext(ctx)
+		if settings.checkQuotas && warnings != nil && d.Id() == "" {
+			warnings.add(checkQuotas(d, meta, warnings, check)...)
+		}
+
+		if customizeDiff != nil {
+			return customizeDiff(ctx, d, meta)
+		}
+		return nil
+	}
+}
+
+// checkQuotas compares the quotas taken by the new resource and by the new resources planned
+// before it with the quotas which are left, and records the quotas taken by the resource.
+func checkQuotas(d *schema.ResourceDiff, meta interface{}, warnings *planWarnings, check quotaCheck) diag.Diagnostics {
+	config, ok := meta.(*config.Config)
+	if !ok {
+		return nil
+	}
+	region := config.Region
+	if v, ok := d.GetOk("region"); ok {
+		region = v.(string)
+	}
+
+	requests, err := check(d, warnings.planned, config, region)
+	if err != nil {
+		return quotaCheckError(err)
+	}
+	warnings.request(region, requests)
+
+	var quotas []quotaUsage
+	seen := make(map[string]bool)
+	for _, request := range requests {
+		if seen[request.Service] {
+			continue
+		}
+		seen[request.Service] = true
+		serviceQuotas, err := warnings.planned.quotas(config, region, request.Service)
+		if err != nil {
+			return quotaCheckError(err)
+		}
+		quotas = append(quotas, serviceQuotas...)
+	}
+
+	var diags diag.Diagnostics
+	for _, request := range requests {
+		for _, quota := range quotas {
+			if quota.Service != request.Service || quota.Type != request.Type || quota.Limit < 0 {
+				continue
+			}
+			planned := warnings.planned.amount(quotaKey{Region: region, Service: request.Service, Type: request.Type})
+			if quota.Used+planned+request.Amount <= quota.Limit {
+				continue
+			}
+
+			detail := fmt.Sprintf("The new resource needs %d of the %s quota of %s in %s, where %d of %d are used",
+				request.Amount, request.Service, request.Type, region, quota.Used, quota.Limit)
+			if planned > 0 {
+				detail += fmt.Sprintf(" and %d are needed by the other new resources of the plan", planned)
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("The %s quota of %s would be exceeded", request.Service, request.Type),
+				Detail:   detail + ".",
+			})
+		}
+	}
+	return diags
+}
+
+func quotaCheckError(err error) diag.Diagnostics {
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Error checking the quotas",
+			Detail:   fmt.Sprintf("The quotas of the new resource were not checked: %s", err),
+		},
+	}
+}
+
+// quotaKey identifies a quota of a region.
+type quotaKey struct {
+	Region  string
+	Service string
+	Type    string
+}
+
+// plannedQuotas adds up the quotas taken by the new resources of a plan. Each resource
+// is planned by its own call of the provider, so the amounts are kept by the provider
+// server from the configuration of the provider on. The quotas and the flavors are
+// queried once per plan, and the quotas used at that time are added to the planned ones.
+type plannedQuotas struct {
+	mu      sync.Mutex
+	amounts map[quotaKey]int
+	queries map[quotaQueryKey]*quotaQuery
+}
+
+// quotaQueryKey identifies the quotas of a service or a flavor of a region.
+type quotaQueryKey struct {
+	Region     string
+	Service    string
+	FlavorID   string
+	FlavorName string
+}
+
+// quotaQuery holds the result of a query, which is done by the first resource that needs it.
+type quotaQuery struct {
+	once   sync.Once
+	quotas []quotaUsage
+	flavor *flavors.Flavor
+	err    error
+}
+
+func newPlannedQuotas() *plannedQuotas {
+	return &plannedQuotas{
+		amounts: make(map[quotaKey]int),
+		queries: make(map[quotaQueryKey]*quotaQuery),
+	}
+}
+
+func (p *plannedQuotas) query(key quotaQueryKey) *quotaQuery {
+	if p == nil {
+		return &quotaQuery{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	q, ok := p.queries[key]
+	if !ok {
+		q = &quotaQuery{}
+		p.queries[key] = q
+	}
+	return q
+}
+
+// quotas returns the quotas of the service in the region.
+func (p *plannedQuotas) quotas(c *config.Config, region, service string) ([]quotaUsage, error) {
+	q := p.query(quotaQueryKey{Region: region, Service: service})
+	q.once.Do(func() {
+		q.quotas, q.err = listServiceQuotas(c, region, service)
+	})
+	return q.quotas, q.err
+}
+
+// flavor returns the compute flavor of the region by its ID, or by its name if the ID is empty.
+func (p *plannedQuotas) flavor(c *config.Config, region, flavorID, flavorName string) (*flavors.Flavor, error) {
+	q := p.query(quotaQueryKey{Region: region, FlavorID: flavorID, FlavorName: flavorName})
+	q.once.Do(func() {
+		q.flavor, q.err = getComputeFlavor(c, region, flavorID, flavorName)
+	})
+	return q.flavor, q.err
+}
+
+// amount returns the amount of the quota taken by the resources planned so far.
+func (p *plannedQuotas) amount(key quotaKey) int {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.amounts[key]
+}
+
+func (p *plannedQuotas) add(amounts map[quotaKey]int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, amount := range amounts {
+		p.amounts[key] += amount
+	}
+}
+
+type planWarningsKey struct{}
+
+// planWarnings collects the warnings of the CustomizeDiff of a resource, which can only return errors,
+// and the quotas taken by the resource, which are added to the planned quotas once it is planned.
+type planWarnings struct {
+	mu        sync.Mutex
+	diags     diag.Diagnostics
+	planned   *plannedQuotas
+	requested map[quotaKey]int
+}
+
+func withPlanWarnings(ctx context.Context, planned *plannedQuotas) (context.Context, *planWarnings) {
+	warnings := &planWarnings{planned: planned}
+	return context.WithValue(ctx, planWarningsKey{}, warnings), warnings
+}
+
+func planWarningsFromContext(ctx context.Context) *planWarnings {
+	warnings, _ := ctx.Value(planWarningsKey{}).(*planWarnings)
+	return warnings
+}
+
+// add adds the warnings, the CustomizeDiff may be called more than once for a plan.
+func (w *planWarnings) add(diags ...diag.Diagnostic) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, d := range diags {
+		duplicate := false
+		for _, existing := range w.diags {
+			if existing.Summary == d.Summary && existing.Detail == d.Detail {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			w.diags = append(w.diags, d)
+		}
+	}
+}
+
+// request records the quotas taken by the resource, replacing the ones of an earlier
+// CustomizeDiff of the same plan, so that the resource is only counted once.
+func (w *planWarnings) request(region string, requests []quotaRequest) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.requested = make(map[quotaKey]int)
+	for _, r := range requests {
+		w.requested[quotaKey{Region: region, Service: r.Service, Type: r.Type}] += r.Amount
+	}
+}
+
+// requests returns the quotas taken by the resource.
+func (w *planWarnings) requests() map[quotaKey]int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.requested
+}
+
+func (w *planWarnings) diagnostics() diag.Diagnostics {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append(diag.Diagnostics(nil), w.diags...)
+}
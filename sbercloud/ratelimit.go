@@ -0,0 +1,248 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// rateLimit caps the requests sent to a service, zero values mean no limit.
+type rateLimit struct {
+	requestsPerSecond float64
+	maxInFlight       int
+}
+
+func (l rateLimit) unlimited() bool {
+	return l.requestsPerSecond <= 0 && l.maxInFlight <= 0
+}
+
+// rateLimitSettings holds the rate limits of the provider: the default limits
+// apply to each service separately, unless the service has its own limits.
+type rateLimitSettings struct {
+	defaults rateLimit
+	services map[string]rateLimit
+}
+
+// expandRateLimitSettings returns the rate limits of the provider, or nil if the rate_limit block is not set.
+func expandRateLimitSettings(d *schema.ResourceData) *rateLimitSettings {
+	raw := d.Get("rate_limit").([]interface{})
+	if len(raw) == 0 || raw[0] == nil {
+		return nil
+	}
+
+	block := raw[0].(map[string]interface{})
+	settings := &rateLimitSettings{
+		defaults: rateLimit{
+			requestsPerSecond: block["requests_per_second"].(float64),
+			maxInFlight:       block["max_in_flight"].(int),
+		},
+		services: make(map[string]rateLimit),
+	}
+
+	for _, v := range block["service"].([]interface{}) {
+		service := v.(map[string]interface{})
+		limit := settings.defaults
+		if rps := service["requests_per_second"].(float64); rps > 0 {
+			limit.requestsPerSecond = rps
+		}
+		if inFlight := service["max_in_flight"].(int); inFlight > 0 {
+			limit.maxInFlight = inFlight
+		}
+		settings.services[service["name"].(string)] = limit
+	}
+
+	return settings
+}
+
+// serviceRateLimits dispatches the requests to the rate limiter of their service.
+// The services of the catalog which share an endpoint, such as ecs and ecsv21,
+// share the rate limiter.
+type serviceRateLimits struct {
+	defaults rateLimit
+	services *serviceHosts
+
+	// the limits of the services which are set in the provider, keyed by the endpoint host
+	limits map[string]rateLimit
+	// the limits of the services in other regions, keyed by the first label of the host
+	labels map[string]rateLimit
+
+	mu       sync.Mutex
+	limiters map[string]*rateLimiter
+}
+
+func newServiceRateLimits(services *serviceHosts, settings *rateLimitSettings) *serviceRateLimits {
+	s := &serviceRateLimits{
+		defaults: settings.defaults,
+		services: services,
+		limits:   make(map[string]rateLimit),
+		labels:   make(map[string]rateLimit),
+		limiters: make(map[string]*rateLimiter),
+	}
+
+	for service, limit := range settings.services {
+		host := services.host(service)
+		if host == "" {
+			continue
+		}
+		// the services which share an endpoint get the lowest limits
+		if current, ok := s.limits[host]; ok {
+			limit = lowerRateLimit(current, limit)
+		}
+		s.limits[host] = limit
+		if !services.custom(service) {
+			s.labels[hostLabel(host)] = lowerRateLimit(s.labels[hostLabel(host)], limit)
+		}
+	}
+
+	return s
+}
+
+func lowerRateLimit(a, b rateLimit) rateLimit {
+	if b.requestsPerSecond > 0 && (a.requestsPerSecond <= 0 || b.requestsPerSecond < a.requestsPerSecond) {
+		a.requestsPerSecond = b.requestsPerSecond
+	}
+	if b.maxInFlight > 0 && (a.maxInFlight <= 0 || b.maxInFlight < a.maxInFlight) {
+		a.maxInFlight = b.maxInFlight
+	}
+	return a
+}
+
+// limiter returns the rate limiter of the host, or nil if the requests to the host are not limited.
+func (s *serviceRateLimits) limiter(host string) *rateLimiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if limiter, ok := s.limiters[host]; ok {
+		return limiter
+	}
+
+	service := s.services.service(host)
+	limit, ok := s.limits[host]
+	if !ok {
+		if limit, ok = s.labels[hostLabel(host)]; !ok {
+			limit = s.defaults
+		}
+	}
+
+	var limiter *rateLimiter
+	if !limit.unlimited() {
+		limiter = newRateLimiter(service, limit)
+	}
+	s.limiters[host] = limiter
+	return limiter
+}
+
+// rateLimiter spaces the requests of a service evenly and caps the requests in flight.
+type rateLimiter struct {
+	service  string
+	interval time.Duration
+	inFlight chan struct{}
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimiter(service string, limit rateLimit) *rateLimiter {
+	limiter := &rateLimiter{service: service}
+	if limit.requestsPerSecond > 0 {
+		limiter.interval = time.Duration(float64(time.Second) / limit.requestsPerSecond)
+	}
+	if limit.maxInFlight > 0 {
+		limiter.inFlight = make(chan struct{}, limit.maxInFlight)
+	}
+	return limiter
+}
+
+// acquire waits until the request can be sent, it returns the time spent waiting.
+func (l *rateLimiter) acquire(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return time.Since(start), ctx.Err()
+		}
+	}
+
+	if l.interval > 0 {
+		l.mu.Lock()
+		now := time.Now()
+		at := l.next
+		if at.Before(now) {
+			at = now
+		}
+		l.next = at.Add(l.interval)
+		l.mu.Unlock()
+
+		if wait := at.Sub(now); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				// give the slot back if it is the last one, the later slots are held by
+				// the waiting requests, which would otherwise share a slot with the next one
+				l.mu.Lock()
+				if l.next.Equal(at.Add(l.interval)) {
+					l.next = at
+				}
+				l.mu.Unlock()
+				l.release()
+				return time.Since(start), ctx.Err()
+			}
+		}
+	}
+
+	return time.Since(start), nil
+}
+
+func (l *rateLimiter) release() {
+	if l.inFlight != nil {
+		<-l.inFlight
+	}
+}
+
+// installRateLimits limits the requests of the clients per service.
+func installRateLimits(c *config.Config, services *serviceHosts, settings *rateLimitSettings) {
+	limits := newServiceRateLimits(services, settings)
+	for _, client := range []*golangsdk.ProviderClient{c.HwClient, c.DomainClient} {
+		if client == nil {
+			continue
+		}
+		client.HTTPClient.Transport = &rateLimitRoundTripper{rt: client.HTTPClient.Transport, limits: limits}
+	}
+}
+
+// rateLimitRoundTripper delays the requests which exceed the rate limits of their service.
+type rateLimitRoundTripper struct {
+	rt     http.RoundTripper
+	limits *serviceRateLimits
+}
+
+func (t *rateLimitRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := t.limits.limiter(req.URL.Host)
+	if limiter == nil {
+		return t.rt.RoundTrip(req)
+	}
+
+	delay, err := limiter.acquire(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("waiting for the rate limit of %s: %s", limiter.service, err)
+	}
+	if delay >= time.Millisecond {
+		log.Printf("[DEBUG] %s %s was delayed by %s by the rate limit of %s",
+			req.Method, req.URL, delay.Round(time.Millisecond), limiter.service)
+	}
+
+	// the request is in flight until its response is received, as some callers keep the body
+	defer limiter.release()
+	return t.rt.RoundTrip(req)
+}
@@ -0,0 +1,301 @@
+package sbercloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func testRateLimitClient(limit rateLimit) *http.Client {
+	limits := &serviceRateLimits{
+		defaults: limit,
+		services: &serviceHosts{},
+		limits:   map[string]rateLimit{},
+		labels:   map[string]rateLimit{},
+		limiters: map[string]*rateLimiter{},
+	}
+	return &http.Client{
+		Transport: &rateLimitRoundTripper{rt: http.DefaultTransport, limits: limits},
+	}
+}
+
+func TestRateLimitRoundTripper_requestsPerSecond(t *testing.T) {
+	server := newTestFlakyServer(t)
+	client := testRateLimitClient(rateLimit{requestsPerSecond: 20})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	// the first request is sent at once, the others 50ms apart
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("expected the requests to be spread over 200ms, took %s", elapsed)
+	}
+}
+
+func TestRateLimitRoundTripper_maxInFlight(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	client := testRateLimitClient(rateLimit{maxInFlight: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("err: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if maxInFlight > 2 {
+		t.Fatalf("expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestRateLimitRoundTripper_contextCancelled(t *testing.T) {
+	server := newTestFlakyServer(t)
+	client := testRateLimitClient(rateLimit{requestsPerSecond: 0.01})
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if _, err := client.Do(req); err == nil {
+		t.Fatalf("expected an error when the context is done while waiting")
+	}
+	if n := len(server.requests()); n != 1 {
+		t.Fatalf("expected the delayed request not to be sent, got %d requests", n)
+	}
+}
+
+func TestRateLimiter_cancelledSlot(t *testing.T) {
+	limiter := newRateLimiter("ecs", rateLimit{requestsPerSecond: 5})
+	if _, err := limiter.acquire(context.Background()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx); err == nil {
+		t.Fatalf("expected an error when the context is done while waiting")
+	}
+
+	// the slot of the cancelled request is taken by the next one
+	delay, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if delay > 300*time.Millisecond {
+		t.Fatalf("expected the next request to take the slot of the cancelled one, it was delayed by %s", delay)
+	}
+}
+
+func TestRateLimiter_cancelledSlotBeforeWaiting(t *testing.T) {
+	limiter := newRateLimiter("ecs", rateLimit{requestsPerSecond: 5})
+	if _, err := limiter.acquire(context.Background()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// reserve a slot which is cancelled while a later slot is held by another request
+	reserve := func(ctx context.Context) (time.Time, chan error) {
+		limiter.mu.Lock()
+		next := limiter.next
+		limiter.mu.Unlock()
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := limiter.acquire(ctx)
+			done <- err
+		}()
+		for {
+			limiter.mu.Lock()
+			reserved := limiter.next.After(next)
+			limiter.mu.Unlock()
+			if reserved {
+				return next, done
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	_, cancelledDone := reserve(cancelled)
+	waiting, stop := context.WithCancel(context.Background())
+	defer stop()
+	reserve(waiting)
+
+	limiter.mu.Lock()
+	next := limiter.next
+	limiter.mu.Unlock()
+
+	cancel()
+	if err := <-cancelledDone; err == nil {
+		t.Fatalf("expected an error when the context is done while waiting")
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	if !limiter.next.Equal(next) {
+		t.Fatalf("expected the slots of the waiting requests to be kept, the next slot moved by %s", limiter.next.Sub(next))
+	}
+}
+
+func TestProvider_rateLimit(t *testing.T) {
+	iam := newTestIAMServer(t)
+	ecs := newTestFlakyServer(t)
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "automation-ak",
+		"secret_key":  "automation-sk",
+		"project_id":  testIAMProjectID,
+		"domain_id":   testIAMDomainID,
+		"max_retries": 0,
+		"rate_limit": []interface{}{
+			map[string]interface{}{
+				"requests_per_second": 10.0,
+				"service": []interface{}{
+					map[string]interface{}{
+						"name":                "ecs",
+						"requests_per_second": 2.0,
+						"max_in_flight":       1,
+					},
+					map[string]interface{}{
+						"name":          "vpc",
+						"max_in_flight": 4,
+					},
+				},
+			},
+		},
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+			"ecs": ecs.URL,
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	transport, ok := meta.(*config.Config).HwClient.HTTPClient.Transport.(*rateLimitRoundTripper)
+	if !ok {
+		t.Fatalf("expected the rate limits to be installed")
+	}
+	limits := transport.limits
+	ecsURL, _ := url.Parse(ecs.URL)
+
+	cases := []struct {
+		host     string
+		service  string
+		interval time.Duration
+		inFlight int
+	}{
+		{ecsURL.Host, "ecs", 500 * time.Millisecond, 1},
+		{"vpc.ru-moscow-1.hc.sbercloud.ru", "vpc", 100 * time.Millisecond, 4},
+		// the limits of the service apply to the other regions
+		{"vpc.ru-moscow-2.hc.sbercloud.ru", "vpc", 100 * time.Millisecond, 4},
+		{"rds.ru-moscow-1.hc.sbercloud.ru", "rds", 100 * time.Millisecond, 0},
+	}
+	for _, tc := range cases {
+		limiter := limits.limiter(tc.host)
+		if limiter == nil {
+			t.Fatalf("expected the requests to %s to be limited", tc.host)
+		}
+		if limiter.service != tc.service {
+			t.Fatalf("expected the service %s of %s, got %s", tc.service, tc.host, limiter.service)
+		}
+		if limiter.interval != tc.interval {
+			t.Fatalf("expected the interval %s for %s, got %s", tc.interval, tc.host, limiter.interval)
+		}
+		if cap(limiter.inFlight) != tc.inFlight {
+			t.Fatalf("expected %d requests in flight for %s, got %d", tc.inFlight, tc.host, cap(limiter.inFlight))
+		}
+	}
+
+	// the catalog entries of the same endpoint share the limiter
+	if limits.limiter("vpc.ru-moscow-1.hc.sbercloud.ru") != limits.limiter(serviceEndpointHost(meta.(*config.Config), "networkv2")) {
+		t.Fatalf("expected vpc and networkv2 to share the rate limiter")
+	}
+}
+
+func TestProvider_rateLimitUnknownService(t *testing.T) {
+	raw := map[string]interface{}{
+		"rate_limit": []interface{}{
+			map[string]interface{}{
+				"service": []interface{}{
+					map[string]interface{}{
+						"name": "ecs2",
+					},
+				},
+			},
+		},
+	}
+
+	diags := Provider().Validate(terraform.NewResourceConfigRaw(raw))
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "ecs2") {
+		t.Fatalf("expected an error for the unknown service, got %v", diags)
+	}
+}
+
+func TestProvider_rateLimitOBS(t *testing.T) {
+	raw := map[string]interface{}{
+		"rate_limit": []interface{}{
+			map[string]interface{}{
+				"service": []interface{}{
+					map[string]interface{}{
+						"name": "obs",
+					},
+				},
+			},
+		},
+	}
+
+	diags := Provider().Validate(terraform.NewResourceConfigRaw(raw))
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "obs") {
+		t.Fatalf("expected an error for the requests of OBS which are not limited, got %v", diags)
+	}
+}
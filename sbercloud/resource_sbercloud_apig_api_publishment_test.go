@@ -0,0 +1,111 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/apigw/v2/apis"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/apig"
+)
+
+func TestAccApigApiPublishmentV2_basic(t *testing.T) {
+	var histories []apis.ApiVersionInfo
+
+	// The dedicated instance name only allow letters, digits and underscores (_).
+	rName := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	resourceName := "sbercloud_apig_api_publishment.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckEpsID(t) // Apig application needs enterprise project ID.
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckApigApiPublishmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApigApiPublishment_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApigApiPublishmentExists(resourceName, &histories),
+					resource.TestCheckResourceAttrPair(resourceName, "instance_id", "sbercloud_apig_instance.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "env_id", "sbercloud_apig_environment.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "api_id", "sbercloud_apig_api.test", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "env_name"),
+					resource.TestCheckResourceAttrSet(resourceName, "publish_time"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckApigApiPublishmentDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.ApigV2Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud APIG v2 client: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_apig_api_publishment" {
+			continue
+		}
+		_, err := apig.GetVersionHistories(client, rs.Primary.Attributes["instance_id"],
+			rs.Primary.Attributes["env_id"], rs.Primary.Attributes["api_id"])
+		if err == nil {
+			return fmt.Errorf("APIG v2 API publishment (%s) is still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckApigApiPublishmentExists(n string, histories *[]apis.ApiVersionInfo) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource %s not found", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("Unable to find API publishment ID")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.ApigV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud APIG v2 client: %s", err)
+		}
+		found, err := apig.GetVersionHistories(client, rs.Primary.Attributes["instance_id"],
+			rs.Primary.Attributes["env_id"], rs.Primary.Attributes["api_id"])
+		if err != nil {
+			return err
+		}
+		*histories = found
+		return nil
+	}
+}
+
+func testAccApigApiPublishment_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_apig_environment" "test" {
+  name        = "%s"
+  instance_id = sbercloud_apig_instance.test.id
+  description = "Created by script"
+}
+
+resource "sbercloud_apig_api_publishment" "test" {
+  instance_id = sbercloud_apig_instance.test.id
+  env_id      = sbercloud_apig_environment.test.id
+  api_id      = sbercloud_apig_api.test.id
+}
+`, testAccApigAPI_basic(rName), rName)
+}
@@ -0,0 +1,209 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// the delay before the status of a restored backup is polled for the first time
+var cbrRestoreStateDelay = 10 * time.Second
+
+// ResourceCBRBackupRestore restores a server or a volume from a CBR backup. The restoration
+// is done on create, the resource is only removed from the state on destroy.
+func ResourceCBRBackupRestore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCBRBackupRestoreCreate,
+		ReadContext:   resourceCBRBackupRestoreRead,
+		DeleteContext: resourceCBRBackupRestoreDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"backup_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"server_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"server_id", "volume_id"},
+			},
+			"volume_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"mappings": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"server_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backup_id": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"volume_id": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			"power_on": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+type cbrRestoreMapping struct {
+	BackupID string `json:"backup_id"`
+	VolumeID string `json:"volume_id"`
+}
+
+type cbrRestoreOpts struct {
+	ServerID string              `json:"server_id,omitempty"`
+	VolumeID string              `json:"volume_id,omitempty"`
+	Mappings []cbrRestoreMapping `json:"mappings,omitempty"`
+	PowerOn  *bool               `json:"power_on,omitempty"`
+}
+
+func resourceCBRBackupRestoreCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*config.Config)
+	client, err := config.CbrV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating SberCloud CBR v3 client: %s", err)
+	}
+
+	backupID := d.Get("backup_id").(string)
+	backup, err := getCBRBackup(client, backupID)
+	if err != nil {
+		return diag.Errorf("Error querying the CBR backup (%s): %s", backupID, err)
+	}
+
+	restoreOpts := cbrRestoreOpts{
+		ServerID: d.Get("server_id").(string),
+		VolumeID: d.Get("volume_id").(string),
+	}
+	if restoreOpts.ServerID != "" {
+		powerOn := d.Get("power_on").(bool)
+		restoreOpts.PowerOn = &powerOn
+		restoreOpts.Mappings, err = buildCBRRestoreMappings(d, backup)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	log.Printf("[DEBUG] Restore Options of the CBR backup (%s): %#v", backupID, restoreOpts)
+
+	_, err = client.Post(client.ServiceURL("backups", backupID, "restore"),
+		map[string]interface{}{"restore": restoreOpts}, nil, &golangsdk.RequestOpts{
+			OkCodes: []int{200, 202},
+		})
+	if err != nil {
+		return diag.Errorf("Error restoring the CBR backup (%s): %s", backupID, err)
+	}
+	d.SetId(backupID)
+
+	// the backup is available before the restoration as well, so the restoration
+	// is only done once the backup has been seen in the restoring status
+	restoring := false
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending", "restoring"},
+		Target:  []string{"available"},
+		Refresh: func() (interface{}, string, error) {
+			b, err := getCBRBackup(client, backupID)
+			if err != nil {
+				return nil, "", err
+			}
+			switch b.Status {
+			case "error":
+				return b, b.Status, fmt.Errorf("the backup is in the error status")
+			case "restoring":
+				restoring = true
+			case "available":
+				if !restoring {
+					return b, "pending", nil
+				}
+			}
+			return b, b.Status, nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      cbrRestoreStateDelay,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("Error waiting for the CBR backup (%s) to be restored: %s", backupID, err)
+	}
+
+	return resourceCBRBackupRestoreRead(ctx, d, meta)
+}
+
+// buildCBRRestoreMappings returns the volumes of the server to restore from the backups of the
+// volumes, which are the children of the server backup. The volumes of the backup are restored
+// to themselves if no mappings are configured.
+func buildCBRRestoreMappings(d *schema.ResourceData, backup *cbrBackup) ([]cbrRestoreMapping, error) {
+	if backup.ResourceType != "OS::Nova::Server" {
+		return nil, fmt.Errorf("the backup %s of the %s %s can not be restored to a server",
+			backup.ID, backup.ResourceType, backup.ResourceID)
+	}
+
+	var mappings []cbrRestoreMapping
+	for _, raw := range d.Get("mappings").([]interface{}) {
+		m := raw.(map[string]interface{})
+		mappings = append(mappings, cbrRestoreMapping{
+			BackupID: m["backup_id"].(string),
+			VolumeID: m["volume_id"].(string),
+		})
+	}
+	if len(mappings) > 0 {
+		return mappings, nil
+	}
+
+	if backup.ResourceID != d.Get("server_id").(string) {
+		return nil, fmt.Errorf("mappings must be specified to restore the backup of the server %s to another server",
+			backup.ResourceID)
+	}
+	for _, child := range backup.Children {
+		mappings = append(mappings, cbrRestoreMapping{
+			BackupID: child.ID,
+			VolumeID: child.ResourceID,
+		})
+	}
+	return mappings, nil
+}
+
+func resourceCBRBackupRestoreRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*config.Config)
+	// the restoration has been done, so it is kept even if the backup is deleted
+	return diag.FromErr(d.Set("region", GetRegion(d, config)))
+}
+
+func resourceCBRBackupRestoreDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,318 @@
+package sbercloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccCBRBackupRestore_volume(t *testing.T) {
+	resourceName := "sbercloud_cbr_backup_restore.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCBRBackup(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCBRBackupRestore_volume(SBC_CBR_BACKUP_ID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", SBC_CBR_BACKUP_ID),
+					resource.TestCheckResourceAttrPair(resourceName, "volume_id", "data.sbercloud_cbr_backup.test", "resource_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCBRBackupRestore_volume(backupID string) string {
+	return fmt.Sprintf(`
+data "sbercloud_cbr_backup" "test" {
+  id = "%s"
+}
+
+resource "sbercloud_cbr_backup_restore" "test" {
+  backup_id = data.sbercloud_cbr_backup.test.id
+  volume_id = data.sbercloud_cbr_backup.test.resource_id
+}
+`, backupID)
+}
+
+// testCBRServer is a local stand-in for the vault, backup and restore APIs of CBR.
+type testCBRServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	vaults   []map[string]interface{}
+	backups  map[string]*cbrBackup
+	queries  []string
+	restores map[string]map[string]interface{}
+	// the number of polls the backup is still available for after the restore request
+	restoreAfter int
+	statuses     []string
+}
+
+func newTestCBRServer(t *testing.T) *testCBRServer {
+	s := &testCBRServer{
+		backups: map[string]*cbrBackup{
+			"server-backup": {
+				ID:           "server-backup",
+				Name:         "autobk_server",
+				VaultID:      "vault-server",
+				ResourceID:   "server-id",
+				ResourceName: "ecs-test",
+				ResourceType: "OS::Nova::Server",
+				ResourceSize: 50,
+				ImageType:    "backup",
+				Status:       "available",
+				Children: []cbrBackup{
+					{ID: "system-backup", ResourceID: "system-volume", ResourceType: "OS::Cinder::Volume", ResourceSize: 40, Status: "available"},
+					{ID: "data-backup", ResourceID: "data-volume", ResourceType: "OS::Cinder::Volume", ResourceSize: 10, Status: "available"},
+				},
+			},
+			"volume-backup": {
+				ID:           "volume-backup",
+				Name:         "autobk_volume",
+				VaultID:      "vault-disk",
+				ResourceID:   "data-volume",
+				ResourceType: "OS::Cinder::Volume",
+				ResourceSize: 10,
+				Status:       "available",
+			},
+		},
+		restores: map[string]map[string]interface{}{},
+	}
+	s.vaults = []map[string]interface{}{
+		{
+			"id":   "vault-server",
+			"name": "vault-server",
+			"billing": map[string]interface{}{
+				"object_type": "server", "consistent_level": "crash_consistent", "protect_type": "backup",
+				"size": 100, "allocated": 51200, "used": 2048, "status": "available",
+			},
+			"resources": []map[string]interface{}{{"id": "server-id", "type": "OS::Nova::Server"}},
+			"tags":      []map[string]interface{}{{"key": "foo", "value": "bar"}},
+		},
+		{
+			"id":   "vault-disk",
+			"name": "vault-disk",
+			"billing": map[string]interface{}{
+				"object_type": "disk", "consistent_level": "crash_consistent", "protect_type": "backup",
+				"size": 40, "allocated": 1024, "used": 512, "status": "available",
+			},
+			"auto_expand": true,
+		},
+	}
+
+	prefix := "/v3/" + testIAMProjectID + "/"
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+		parts := strings.Split(path, "/")
+		switch {
+		case r.Method == http.MethodGet && path == "vaults":
+			s.queries = append(s.queries, r.URL.RawQuery)
+			result := make([]map[string]interface{}, 0)
+			for _, v := range s.vaults {
+				objectType := r.URL.Query().Get("object_type")
+				if objectType == "" || v["billing"].(map[string]interface{})["object_type"] == objectType {
+					result = append(result, v)
+				}
+			}
+			writeTestJSON(w, map[string]interface{}{"vaults": result, "count": len(result)})
+		case r.Method == http.MethodGet && len(parts) == 2 && parts[0] == "backups":
+			backup, ok := s.backups[parts[1]]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeTestJSON(w, map[string]interface{}{"backup": backup})
+			s.statuses = append(s.statuses, backup.Status)
+			// the restoration starts after restoreAfter polls, and is done after it has been polled once
+			if _, ok := s.restores[backup.ID]; ok && backup.Status == "available" && s.restoreAfter > 0 {
+				s.restoreAfter--
+				if s.restoreAfter == 0 {
+					backup.Status = "restoring"
+				}
+			} else if backup.Status == "restoring" {
+				backup.Status = "available"
+			}
+		case r.Method == http.MethodPost && len(parts) == 3 && parts[0] == "backups" && parts[2] == "restore":
+			backup, ok := s.backups[parts[1]]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			var body map[string]map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.restores[backup.ID] = body["restore"]
+			if s.restoreAfter == 0 {
+				backup.Status = "restoring"
+			}
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testCBRServer) endpoints() map[string]interface{} {
+	return map[string]interface{}{
+		"cbr": s.URL + "/",
+	}
+}
+
+func (s *testCBRServer) polledStatuses() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.statuses
+}
+
+func (s *testCBRServer) restore(id string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.restores[id]
+}
+
+func testCBRBackupRestore(t *testing.T, meta interface{}, raw map[string]interface{}) (*schema.ResourceData, error) {
+	stateDelay := cbrRestoreStateDelay
+	cbrRestoreStateDelay = 0
+	defer func() { cbrRestoreStateDelay = stateDelay }()
+
+	d := schema.TestResourceDataRaw(t, ResourceCBRBackupRestore().Schema, raw)
+	diags := resourceCBRBackupRestoreCreate(context.Background(), d, meta)
+	if diags.HasError() {
+		return d, fmt.Errorf("%s", diags[0].Summary)
+	}
+	return d, nil
+}
+
+func TestResourceCBRBackupRestore_server(t *testing.T) {
+	cbr := newTestCBRServer(t)
+	meta := testProviderMeta(t, cbr.endpoints())
+
+	d, err := testCBRBackupRestore(t, meta, map[string]interface{}{
+		"backup_id": "server-backup",
+		"server_id": "server-id",
+		"power_on":  false,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Id() != "server-backup" || d.Get("region") != "ru-moscow-1" {
+		t.Fatalf("unexpected state: id %q, region %q", d.Id(), d.Get("region"))
+	}
+
+	expected := map[string]interface{}{
+		"server_id": "server-id",
+		"power_on":  false,
+		"mappings": []interface{}{
+			map[string]interface{}{"backup_id": "system-backup", "volume_id": "system-volume"},
+			map[string]interface{}{"backup_id": "data-backup", "volume_id": "data-volume"},
+		},
+	}
+	if got := cbr.restore("server-backup"); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected the restore request %v, got %v", expected, got)
+	}
+}
+
+func TestResourceCBRBackupRestore_serverMappings(t *testing.T) {
+	cbr := newTestCBRServer(t)
+	meta := testProviderMeta(t, cbr.endpoints())
+
+	_, err := testCBRBackupRestore(t, meta, map[string]interface{}{
+		"backup_id": "server-backup",
+		"server_id": "another-server-id",
+	})
+	if err == nil || !strings.Contains(err.Error(), "mappings must be specified") {
+		t.Fatalf("expected the missing mappings to be reported, got %v", err)
+	}
+	if cbr.restore("server-backup") != nil {
+		t.Fatalf("the backup should not be restored without mappings")
+	}
+
+	_, err = testCBRBackupRestore(t, meta, map[string]interface{}{
+		"backup_id": "server-backup",
+		"server_id": "another-server-id",
+		"mappings": []interface{}{
+			map[string]interface{}{"backup_id": "system-backup", "volume_id": "another-volume"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"server_id": "another-server-id",
+		"power_on":  true,
+		"mappings": []interface{}{
+			map[string]interface{}{"backup_id": "system-backup", "volume_id": "another-volume"},
+		},
+	}
+	if got := cbr.restore("server-backup"); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected the restore request %v, got %v", expected, got)
+	}
+}
+
+func TestResourceCBRBackupRestore_volume(t *testing.T) {
+	cbr := newTestCBRServer(t)
+	meta := testProviderMeta(t, cbr.endpoints())
+
+	if _, err := testCBRBackupRestore(t, meta, map[string]interface{}{
+		"backup_id": "volume-backup",
+		"volume_id": "data-volume",
+	}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]interface{}{"volume_id": "data-volume"}
+	if got := cbr.restore("volume-backup"); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected the restore request %v, got %v", expected, got)
+	}
+
+	// the backup of a volume can not be restored to a server
+	_, err := testCBRBackupRestore(t, meta, map[string]interface{}{
+		"backup_id": "volume-backup",
+		"server_id": "server-id",
+	})
+	if err == nil || !strings.Contains(err.Error(), "can not be restored to a server") {
+		t.Fatalf("expected the volume backup to be rejected, got %v", err)
+	}
+}
+
+func TestResourceCBRBackupRestore_waitsForRestoring(t *testing.T) {
+	cbr := newTestCBRServer(t)
+	cbr.restoreAfter = 1
+	meta := testProviderMeta(t, cbr.endpoints())
+
+	if _, err := testCBRBackupRestore(t, meta, map[string]interface{}{
+		"backup_id": "volume-backup",
+		"volume_id": "data-volume",
+	}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// the backup is queried before the restoration, and is still available when it is polled first
+	expected := []string{"available", "available", "restoring", "available"}
+	if got := cbr.polledStatuses(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected the polled statuses %v, got %v", expected, got)
+	}
+}
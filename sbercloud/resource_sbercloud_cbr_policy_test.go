@@ -0,0 +1,139 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/cbr/v3/policies"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccCBRPolicy_basic(t *testing.T) {
+	var policy policies.Policy
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cbr_policy.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCBRPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCBRPolicy_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCBRPolicyExists(resourceName, &policy),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "type", "backup"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "time_period", "20"),
+					resource.TestCheckResourceAttr(resourceName, "backup_cycle.0.days", "MO,TH"),
+					resource.TestCheckResourceAttr(resourceName, "backup_cycle.0.execution_times.#", "2"),
+				),
+			},
+			{
+				Config: testAccCBRPolicy_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCBRPolicyExists(resourceName, &policy),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-update"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "time_period", "30"),
+					resource.TestCheckResourceAttr(resourceName, "backup_cycle.0.interval", "5"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCBRPolicyDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.CbrV3Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CBR client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_cbr_policy" {
+			continue
+		}
+
+		_, err := policies.Get(client, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("CBR policy still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckCBRPolicyExists(n string, policy *policies.Policy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.CbrV3Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud CBR client: %s", err)
+		}
+
+		found, err := policies.Get(client, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("CBR policy not found")
+		}
+
+		*policy = *found
+
+		return nil
+	}
+}
+
+func testAccCBRPolicy_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_cbr_policy" "test" {
+  name        = "%s"
+  type        = "backup"
+  time_period = 20
+
+  backup_cycle {
+    days            = "MO,TH"
+    execution_times = ["06:00", "18:00"]
+  }
+}
+`, rName)
+}
+
+func testAccCBRPolicy_update(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_cbr_policy" "test" {
+  name        = "%s-update"
+  type        = "backup"
+  enabled     = false
+  time_period = 30
+
+  backup_cycle {
+    interval        = 5
+    execution_times = ["21:00"]
+  }
+}
+`, rName)
+}
@@ -0,0 +1,149 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/cbr/v3/vaults"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccCBRVault_disk(t *testing.T) {
+	var vault vaults.Vault
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cbr_vault.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCBRVaultDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCBRVault_disk(rName, 50),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCBRVaultExists(resourceName, &vault),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "type", "disk"),
+					resource.TestCheckResourceAttr(resourceName, "consistent_level", "crash_consistent"),
+					resource.TestCheckResourceAttr(resourceName, "protection_type", "backup"),
+					resource.TestCheckResourceAttr(resourceName, "size", "50"),
+					resource.TestCheckResourceAttr(resourceName, "resources.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+					resource.TestCheckResourceAttrPair(resourceName, "policy_id", "sbercloud_cbr_policy.test", "id"),
+				),
+			},
+			{
+				Config: testAccCBRVault_disk(rName+"-update", 100),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCBRVaultExists(resourceName, &vault),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-update"),
+					resource.TestCheckResourceAttr(resourceName, "size", "100"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCBRVaultDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.CbrV3Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CBR client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_cbr_vault" {
+			continue
+		}
+
+		_, err := vaults.Get(client, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("CBR vault still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckCBRVaultExists(n string, vault *vaults.Vault) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.CbrV3Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud CBR client: %s", err)
+		}
+
+		found, err := vaults.Get(client, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("CBR vault not found")
+		}
+
+		*vault = *found
+
+		return nil
+	}
+}
+
+func testAccCBRVault_disk(rName string, size int) string {
+	return fmt.Sprintf(`
+data "sbercloud_availability_zones" "test" {}
+
+resource "sbercloud_evs_volume" "test" {
+  name              = "%[1]s"
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+  volume_type       = "SAS"
+  size              = 20
+}
+
+resource "sbercloud_cbr_policy" "test" {
+  name        = "%[1]s"
+  type        = "backup"
+  time_period = 20
+
+  backup_cycle {
+    days            = "MO,TH"
+    execution_times = ["02:00"]
+  }
+}
+
+resource "sbercloud_cbr_vault" "test" {
+  name             = "%[1]s"
+  type             = "disk"
+  consistent_level = "crash_consistent"
+  protection_type  = "backup"
+  size             = %[2]d
+  policy_id        = sbercloud_cbr_policy.test.id
+
+  resources {
+    includes = [sbercloud_evs_volume.test.id]
+  }
+
+  tags = {
+    foo = "bar"
+  }
+}
+`, rName, size)
+}
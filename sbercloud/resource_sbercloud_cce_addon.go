@@ -0,0 +1,97 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/chnsz/golangsdk/openstack/cce/v3/templates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// ResourceCCEAddon is the CCE add-on of the huaweicloud provider, whose template and version
+// are checked at plan time against the add-on templates published for the cluster.
+// The add-on catalog of SberCloud differs from the one of HuaweiCloud, and an add-on
+// which is not published is only rejected by CCE after the nodes have been created.
+func ResourceCCEAddon() *schema.Resource {
+	r := huaweicloud.ResourceCCEAddonV3()
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if err := checkCCEAddonTemplate(d, meta); err != nil {
+			return err
+		}
+
+		if customizeDiff != nil {
+			return customizeDiff(ctx, d, meta)
+		}
+		return nil
+	}
+	return r
+}
+
+// checkCCEAddonTemplate checks the template and the version of a new add-on. The add-ons
+// of the clusters which are not created yet are not checked, and the errors of listing the
+// templates are left to the creation.
+func checkCCEAddonTemplate(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("cluster_id") && !d.HasChange("template_name") && !d.HasChange("version") {
+		return nil
+	}
+	for _, key := range []string{"cluster_id", "template_name", "version"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	config, ok := meta.(*config.Config)
+	if !ok {
+		return nil
+	}
+	region := config.Region
+	if v, ok := d.GetOk("region"); ok && d.NewValueKnown("region") {
+		region = v.(string)
+	}
+	client, err := config.CceAddonV3Client(region)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE add-on client: %s", err)
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+	templateList, err := templates.List(client, clusterID).Extract()
+	if err != nil {
+		log.Printf("[WARN] The add-on templates of the CCE cluster (%s) were not listed: %s", clusterID, err)
+		return nil
+	}
+	return checkCCEAddonTemplateVersion(templateList, clusterID, d.Get("template_name").(string),
+		d.Get("version").(string))
+}
+
+// checkCCEAddonTemplateVersion returns an error listing the published templates or versions
+// when the version of the template is not published for the cluster.
+func checkCCEAddonTemplateVersion(templateList []templates.Template, clusterID, name, version string) error {
+	names := make([]string, 0, len(templateList))
+	for _, template := range templateList {
+		if template.Metadata.Name != name {
+			names = append(names, template.Metadata.Name)
+			continue
+		}
+
+		versions := make([]string, 0, len(template.Spec.Versions))
+		for _, v := range template.Spec.Versions {
+			if v.Version == version {
+				return nil
+			}
+			versions = append(versions, v.Version)
+		}
+		return fmt.Errorf("the version %s of the add-on template %s is not published for the CCE cluster (%s), "+
+			"the published versions are: %s", version, name, clusterID, strings.Join(versions, ", "))
+	}
+
+	sort.Strings(names)
+	return fmt.Errorf("the add-on template %s is not published for the CCE cluster (%s), "+
+		"the published templates are: %s", name, clusterID, strings.Join(names, ", "))
+}
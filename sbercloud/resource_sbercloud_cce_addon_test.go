@@ -0,0 +1,297 @@
+package sbercloud
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/cce/v3/addons"
+	"github.com/chnsz/golangsdk/openstack/cce/v3/templates"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccCCEAddonV3_basic(t *testing.T) {
+	var addon addons.Addon
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cce_addon.test"
+	clusterName := "sbercloud_cce_cluster.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCCEAddon(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCEAddonV3Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEAddonV3_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCEAddonV3Exists(resourceName, clusterName, &addon),
+					resource.TestCheckResourceAttr(resourceName, "version", SBC_CCE_METRICS_SERVER_VERSION),
+					resource.TestCheckResourceAttr(resourceName, "status", "running"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccCCEAddonImportStateIdFunc(),
+			},
+		},
+	})
+}
+
+func TestAccCCEAddonV3_values(t *testing.T) {
+	var addon addons.Addon
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "sbercloud_cce_addon.test"
+	clusterName := "sbercloud_cce_cluster.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckCCEAddon(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCEAddonV3Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEAddonV3_values(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCEAddonV3Exists(resourceName, clusterName, &addon),
+					resource.TestCheckResourceAttr(resourceName, "status", "available"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCCEAddonV3_unpublishedVersion(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCEClusterV3Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCEClusterV3_basic(rName),
+			},
+			{
+				Config:      testAccCCEAddonV3_unpublishedVersion(rName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("the version 0.0.1 of the add-on template metrics-server is not published"),
+			},
+		},
+	})
+}
+
+func testAccCheckCCEAddonV3Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	cceClient, err := config.CceAddonV3Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE Addon client: %s", err)
+	}
+
+	var clusterId string
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "sbercloud_cce_cluster" {
+			clusterId = rs.Primary.ID
+		}
+
+		if rs.Type != "sbercloud_cce_addon" {
+			continue
+		}
+
+		if clusterId != "" {
+			_, err := addons.Get(cceClient, rs.Primary.ID, clusterId).Extract()
+			if err == nil {
+				return fmt.Errorf("addon still exists")
+			}
+		}
+	}
+	return nil
+}
+
+func testAccCheckCCEAddonV3Exists(n string, cluster string, addon *addons.Addon) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		c, ok := s.RootModule().Resources[cluster]
+		if !ok {
+			return fmt.Errorf("Cluster not found: %s", c)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+		if c.Primary.ID == "" {
+			return fmt.Errorf("Cluster id is not set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		cceClient, err := config.CceAddonV3Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud CCE Addon client: %s", err)
+		}
+
+		found, err := addons.Get(cceClient, rs.Primary.ID, c.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.Metadata.Id != rs.Primary.ID {
+			return fmt.Errorf("Addon not found")
+		}
+
+		*addon = *found
+
+		return nil
+	}
+}
+
+func testAccCCEAddonImportStateIdFunc() resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		var clusterID string
+		var addonID string
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type == "sbercloud_cce_cluster" {
+				clusterID = rs.Primary.ID
+			} else if rs.Type == "sbercloud_cce_addon" {
+				addonID = rs.Primary.ID
+			}
+		}
+		if clusterID == "" || addonID == "" {
+			return "", fmt.Errorf("resource not found: %s/%s", clusterID, addonID)
+		}
+		return fmt.Sprintf("%s/%s", clusterID, addonID), nil
+	}
+}
+
+func testAccCCEAddonV3_Base(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_cce_node" "test" {
+  cluster_id        = sbercloud_cce_cluster.test.id
+  name              = "%s"
+  flavor_id         = "s6.large.2"
+  availability_zone = data.sbercloud_availability_zones.test.names[0]
+  key_pair          = sbercloud_compute_keypair.test.name
+
+  root_volume {
+    size       = 40
+    volumetype = "SSD"
+  }
+  data_volumes {
+    size       = 100
+    volumetype = "SSD"
+  }
+}
+`, testAccCCENodeV3_Base(rName), rName)
+}
+
+func testAccCCEAddonV3_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_cce_addon" "test" {
+  cluster_id    = sbercloud_cce_cluster.test.id
+  version       = "%s"
+  template_name = "metrics-server"
+  depends_on    = [sbercloud_cce_node.test]
+}
+`, testAccCCEAddonV3_Base(rName), SBC_CCE_METRICS_SERVER_VERSION)
+}
+
+func testAccCCEAddonV3_values(rName string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "sbercloud_cce_addon_template" "test" {
+  cluster_id = sbercloud_cce_cluster.test.id
+  name       = "autoscaler"
+  version    = "%[2]s"
+}
+
+resource "sbercloud_cce_addon" "test" {
+  cluster_id    = sbercloud_cce_cluster.test.id
+  template_name = "autoscaler"
+  version       = "%[2]s"
+
+  values {
+    basic  = jsondecode(data.sbercloud_cce_addon_template.test.spec).basic
+    custom = merge(
+      jsondecode(data.sbercloud_cce_addon_template.test.spec).parameters.custom,
+      {
+        cluster_id = sbercloud_cce_cluster.test.id
+        tenant_id  = data.sbercloud_caller_identity.current.project_id
+      }
+    )
+    flavor_json = jsonencode(jsondecode(data.sbercloud_cce_addon_template.test.spec).parameters.flavor2)
+  }
+
+  depends_on = [sbercloud_cce_node.test]
+}
+
+data "sbercloud_caller_identity" "current" {}
+`, testAccCCEAddonV3_Base(rName), SBC_CCE_AUTOSCALER_VERSION)
+}
+
+func testAccCCEAddonV3_unpublishedVersion(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_cce_addon" "test" {
+  cluster_id    = sbercloud_cce_cluster.test.id
+  version       = "0.0.1"
+  template_name = "metrics-server"
+}
+`, testAccCCEClusterV3_basic(rName))
+}
+
+func testCCEAddonTemplates() []templates.Template {
+	var metricsServer, autoscaler templates.Template
+	metricsServer.Metadata.Name = "metrics-server"
+	metricsServer.Spec.Versions = []addons.Versions{{Version: "1.0.6"}, {Version: "1.1.2"}}
+	autoscaler.Metadata.Name = "autoscaler"
+	autoscaler.Spec.Versions = []addons.Versions{{Version: "1.19.6"}}
+	return []templates.Template{metricsServer, autoscaler}
+}
+
+func TestCheckCCEAddonTemplateVersion(t *testing.T) {
+	if err := checkCCEAddonTemplateVersion(testCCEAddonTemplates(), "cluster-1", "metrics-server", "1.1.2"); err != nil {
+		t.Fatalf("expected the published version to be accepted, got %s", err)
+	}
+}
+
+func TestCheckCCEAddonTemplateVersion_version(t *testing.T) {
+	err := checkCCEAddonTemplateVersion(testCCEAddonTemplates(), "cluster-1", "metrics-server", "1.1.0")
+	if err == nil {
+		t.Fatalf("expected the version which is not published to be rejected")
+	}
+	if !strings.Contains(err.Error(), "the published versions are: 1.0.6, 1.1.2") {
+		t.Fatalf("expected the published versions to be listed, got %s", err)
+	}
+}
+
+func TestCheckCCEAddonTemplateVersion_template(t *testing.T) {
+	err := checkCCEAddonTemplateVersion(testCCEAddonTemplates(), "cluster-1", "everest", "1.2.0")
+	if err == nil {
+		t.Fatalf("expected the template which is not published to be rejected")
+	}
+	if !strings.Contains(err.Error(), "the published templates are: autoscaler, metrics-server") {
+		t.Fatalf("expected the published templates to be listed, got %s", err)
+	}
+}
@@ -0,0 +1,156 @@
+package sbercloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/cce/v1/namespaces"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccCCENamespaceV1_basic(t *testing.T) {
+	var namespace namespaces.Namespace
+	resourceName := "sbercloud_cce_namespace.test"
+	randName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCENamespaceV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCENamespaceV1_basic(randName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCENamespaceV1Exists(resourceName, &namespace),
+					resource.TestCheckResourceAttrPair(resourceName, "cluster_id", "sbercloud_cce_cluster.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "name", randName),
+					resource.TestCheckResourceAttr(resourceName, "status", "Active"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccCCENamespaceImportStateIdFunc(randName),
+			},
+		},
+	})
+}
+
+func TestAccCCENamespaceV1_generateName(t *testing.T) {
+	var namespace namespaces.Namespace
+	resourceName := "sbercloud_cce_namespace.test"
+	randName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCCENamespaceV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCCENamespaceV1_generateName(randName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCCENamespaceV1Exists(resourceName, &namespace),
+					resource.TestCheckResourceAttrPair(resourceName, "cluster_id", "sbercloud_cce_cluster.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "prefix", randName),
+					resource.TestCheckResourceAttr(resourceName, "status", "Active"),
+					resource.TestMatchResourceAttr(resourceName, "name", regexp.MustCompile(fmt.Sprintf(`^%s[a-z0-9-]*`, randName))),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCCENamespaceV1Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.CceV1Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud CCE v1 client: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_cce_namespace" {
+			continue
+		}
+		found, err := namespaces.Get(client, rs.Primary.Attributes["cluster_id"],
+			rs.Primary.Attributes["name"]).Extract()
+		if err == nil && found != nil {
+			return fmt.Errorf("CCE namespace (%s) still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckCCENamespaceV1Exists(n string, v *namespaces.Namespace) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource %s not found", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.CceV1Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud CCE v1 client: %s", err)
+		}
+		found, err := namespaces.Get(client, rs.Primary.Attributes["cluster_id"],
+			rs.Primary.Attributes["name"]).Extract()
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return fmt.Errorf("CCE namespace (%s) not found", rs.Primary.ID)
+		}
+		*v = *found
+		return nil
+	}
+}
+
+func testAccCCENamespaceImportStateIdFunc(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		var clusterID string
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type == "sbercloud_cce_cluster" {
+				clusterID = rs.Primary.ID
+			}
+		}
+		if clusterID == "" || name == "" {
+			return "", fmt.Errorf("resource not found: %s/%s", clusterID, name)
+		}
+		return fmt.Sprintf("%s/%s", clusterID, name), nil
+
+	}
+}
+
+func testAccCCENamespaceV1_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_cce_namespace" "test" {
+  cluster_id = sbercloud_cce_cluster.test.id
+  name       = "%s"
+}
+`, testAccCceCluster_config(rName), rName)
+}
+
+func testAccCCENamespaceV1_generateName(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_cce_namespace" "test" {
+  cluster_id = sbercloud_cce_cluster.test.id
+  prefix     = "%s"
+}
+`, testAccCceCluster_config(rName), rName)
+}
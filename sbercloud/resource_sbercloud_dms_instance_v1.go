@@ -1,13 +1,16 @@
 package sbercloud
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/common/tags"
 	"github.com/chnsz/golangsdk/openstack/dms/v1/instances"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -15,15 +18,27 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
+// the delay before the status of a DMS instance is polled for the first time
+var dmsInstanceStateDelay = 10 * time.Second
+
 func ResourceDmsInstancesV1() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDmsInstancesV1Create,
-		Read:   resourceDmsInstancesV1Read,
-		Update: resourceDmsInstancesV1Update,
-		Delete: resourceDmsInstancesV1Delete,
+		CreateContext: resourceDmsInstancesV1Create,
+		ReadContext:   resourceDmsInstancesV1Read,
+		UpdateContext: resourceDmsInstancesV1Update,
+		DeleteContext: resourceDmsInstancesV1Delete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(50 * time.Minute),
+			Update: schema.DefaultTimeout(50 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
+
+		CustomizeDiff: resourceDmsInstancesV1CustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -61,10 +76,12 @@ func ResourceDmsInstancesV1() *schema.Resource {
 			"storage_spec_code": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"access_user": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 			},
 			"password": {
 				Type:      schema.TypeString,
@@ -74,6 +91,7 @@ func ResourceDmsInstancesV1() *schema.Resource {
 			"vpc_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"security_group_id": {
 				Type:     schema.TypeString,
@@ -82,10 +100,12 @@ func ResourceDmsInstancesV1() *schema.Resource {
 			"subnet_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"available_zones": {
 				Type:     schema.TypeList,
 				Required: true,
+				ForceNew: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 			"product_id": {
@@ -105,6 +125,7 @@ func ResourceDmsInstancesV1() *schema.Resource {
 			"partition_num": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
 			"specification": {
 				Type:     schema.TypeString,
@@ -128,6 +149,14 @@ func ResourceDmsInstancesV1() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"user_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"instance_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"order_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -164,11 +193,11 @@ func ResourceDmsInstancesV1() *schema.Resource {
 	}
 }
 
-func resourceDmsInstancesV1Create(d *schema.ResourceData, meta interface{}) error {
+func resourceDmsInstancesV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*config.Config)
 	dmsV1Client, err := config.DmsV1Client(GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating SberCloud dms instance client: %s", err)
+		return diag.Errorf("Error creating SberCloud dms instance client: %s", err)
 	}
 
 	ssl_enable := false
@@ -201,7 +230,7 @@ func resourceDmsInstancesV1Create(d *schema.ResourceData, meta interface{}) erro
 
 	v, err := instances.Create(dmsV1Client, createOpts).Extract()
 	if err != nil {
-		return fmt.Errorf("Error creating SberCloud instance: %s", err)
+		return diag.Errorf("Error creating SberCloud instance: %s", err)
 	}
 	log.Printf("[INFO] instance ID: %s", v.InstanceID)
 
@@ -210,12 +239,12 @@ func resourceDmsInstancesV1Create(d *schema.ResourceData, meta interface{}) erro
 		Target:     []string{"RUNNING"},
 		Refresh:    DmsInstancesV1StateRefreshFunc(dmsV1Client, v.InstanceID),
 		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      10 * time.Second,
+		Delay:      dmsInstanceStateDelay,
 		MinTimeout: 3 * time.Second,
 	}
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error waiting for instance (%s) to become ready: %s",
 			v.InstanceID, err)
 	}
@@ -223,34 +252,45 @@ func resourceDmsInstancesV1Create(d *schema.ResourceData, meta interface{}) erro
 	// Store the instance ID now
 	d.SetId(v.InstanceID)
 
-	//set tags
+	// the instance has been created, so the tags which can not be set are
+	// reported as a warning and are corrected by the next apply
+	var diags diag.Diagnostics
 	tagRaw := d.Get("tags").(map[string]interface{})
 	if len(tagRaw) > 0 {
 		dmsV2Client, err := config.DmsV2Client(GetRegion(d, config))
 		if err != nil {
-			return fmt.Errorf("Error creating SberCloud dms instance v2 client: %s", err)
+			return diag.Errorf("Error creating SberCloud dms instance v2 client: %s", err)
 		}
 
 		taglist := utils.ExpandResourceTags(tagRaw)
 		engine := d.Get("engine").(string)
 		if tagErr := tags.Create(dmsV2Client, engine, v.InstanceID, taglist).ExtractErr(); tagErr != nil {
-			log.Printf("[WARN] fetching tags of DMS instance failed: %s", tagErr)
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Error setting tags of the DMS instance",
+				Detail:   fmt.Sprintf("Error setting tags of dms instance (%s): %s", v.InstanceID, tagErr),
+			})
 		}
 	}
 
-	return resourceDmsInstancesV1Read(d, meta)
+	return append(diags, resourceDmsInstancesV1Read(ctx, d, meta)...)
 }
 
-func resourceDmsInstancesV1Read(d *schema.ResourceData, meta interface{}) error {
+func resourceDmsInstancesV1Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*config.Config)
 
 	dmsV1Client, err := config.DmsV1Client(GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating SberCloud dms instance client: %s", err)
+		return diag.Errorf("Error creating SberCloud dms instance client: %s", err)
+	}
+	result := instances.Get(dmsV1Client, d.Id())
+	v, err := result.Extract()
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "DMS instance"))
 	}
-	v, err := instances.Get(dmsV1Client, d.Id()).Extract()
+	partitionNum, err := extractDmsPartitionNum(result)
 	if err != nil {
-		return CheckDeleted(d, err, "DMS instance")
+		return diag.Errorf("Error extracting the partitions of dms instance (%s): %s", d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] Dms instance %s: %+v", d.Id(), v)
@@ -260,9 +300,11 @@ func resourceDmsInstancesV1Read(d *schema.ResourceData, meta interface{}) error
 	d.Set("engine", v.Engine)
 	d.Set("engine_version", v.EngineVersion)
 	d.Set("specification", v.Specification)
+	d.Set("partition_num", partitionNum)
+	d.Set("storage_space", v.StorageSpace)
 	d.Set("used_storage_space", v.UsedStorageSpace)
 	d.Set("connect_address", v.ConnectAddress)
-	d.Set("port", v.Port)
+	d.Set("port", strconv.Itoa(v.Port))
 	d.Set("status", v.Status)
 	d.Set("description", v.Description)
 	d.Set("instance_id", v.InstanceID)
@@ -285,32 +327,38 @@ func resourceDmsInstancesV1Read(d *schema.ResourceData, meta interface{}) error
 	// set tags
 	dmsV2Client, err := config.DmsV2Client(GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating SberCloud dms instance v2 client: %s", err)
+		return diag.Errorf("Error creating SberCloud dms instance v2 client: %s", err)
 	}
 
 	engine := d.Get("engine").(string)
-	if resourceTags, err := tags.Get(dmsV2Client, engine, d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
-		if err := d.Set("tags", tagmap); err != nil {
-			return fmt.Errorf("Error saving tags to state for dms instance (%s): %s", d.Id(), err)
+	resourceTags, err := tags.Get(dmsV2Client, engine, d.Id()).Extract()
+	if err != nil {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Error fetching tags of the DMS instance",
+				Detail:   fmt.Sprintf("Error fetching tags of dms instance (%s): %s", d.Id(), err),
+			},
 		}
-	} else {
-		log.Printf("[WARN] Error fetching tags of dms instance (%s): %s", d.Id(), err)
+	}
+
+	tagmap := utils.TagsToMap(resourceTags.Tags)
+	if err := d.Set("tags", tagmap); err != nil {
+		return diag.Errorf("Error saving tags to state for dms instance (%s): %s", d.Id(), err)
 	}
 
 	return nil
 }
 
-func resourceDmsInstancesV1Update(d *schema.ResourceData, meta interface{}) error {
+func resourceDmsInstancesV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*config.Config)
+	dmsV1Client, err := config.DmsV1Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error updating SberCloud dms instance client: %s", err)
+	}
 
 	//lintignore:R019
 	if d.HasChanges("name", "description", "maintain_begin", "maintain_end", "security_group_id") {
-		dmsV1Client, err := config.DmsV1Client(GetRegion(d, config))
-		if err != nil {
-			return fmt.Errorf("Error updating SberCloud dms instance client: %s", err)
-		}
-
 		var updateOpts instances.UpdateOpts
 		if d.HasChange("name") {
 			updateOpts.Name = d.Get("name").(string)
@@ -334,41 +382,66 @@ func resourceDmsInstancesV1Update(d *schema.ResourceData, meta interface{}) erro
 
 		err = instances.Update(dmsV1Client, d.Id(), updateOpts).Err
 		if err != nil {
-			return fmt.Errorf("Error updating SberCloud Dms Instance: %s", err)
+			return diag.Errorf("Error updating SberCloud Dms Instance: %s", err)
 		}
 	}
 
+	//lintignore:R019
+	if d.HasChanges("product_id", "storage_space", "specification", "partition_num") {
+		if err := resizeDmsInstanceV1(ctx, d, dmsV1Client); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("password") {
+		resetOpts := map[string]interface{}{
+			"new_password": d.Get("password").(string),
+		}
+		_, err := dmsV1Client.Put(dmsV1Client.ServiceURL("instances", d.Id(), "password"), resetOpts, nil,
+			&golangsdk.RequestOpts{
+				OkCodes: []int{200, 204},
+			})
+		if err != nil {
+			return diag.Errorf("Error resetting the password of SberCloud dms instance (%s): %s", d.Id(), err)
+		}
+	}
+
+	var diags diag.Diagnostics
 	if d.HasChange("tags") {
 		dmsV2Client, err := config.DmsV2Client(GetRegion(d, config))
 		if err != nil {
-			return fmt.Errorf("Error updating SberCloud dms instance v2 client: %s", err)
+			return diag.Errorf("Error updating SberCloud dms instance v2 client: %s", err)
 		}
 		// update tags
 		engine := d.Get("engine").(string)
 		tagErr := utils.UpdateResourceTags(dmsV2Client, d, engine, d.Id())
 		if tagErr != nil {
-			return fmt.Errorf("Error updating tags of dms instance:%s, err:%s", d.Id(), tagErr)
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Error updating tags of the DMS instance",
+				Detail:   fmt.Sprintf("Error updating tags of dms instance (%s): %s", d.Id(), tagErr),
+			})
 		}
 	}
 
-	return resourceDmsInstancesV1Read(d, meta)
+	return append(diags, resourceDmsInstancesV1Read(ctx, d, meta)...)
 }
 
-func resourceDmsInstancesV1Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceDmsInstancesV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*config.Config)
 	dmsV1Client, err := config.DmsV1Client(GetRegion(d, config))
 	if err != nil {
-		return fmt.Errorf("Error creating SberCloud dms instance client: %s", err)
+		return diag.Errorf("Error creating SberCloud dms instance client: %s", err)
 	}
 
 	_, err = instances.Get(dmsV1Client, d.Id()).Extract()
 	if err != nil {
-		return CheckDeleted(d, err, "instance")
+		return diag.FromErr(CheckDeleted(d, err, "instance"))
 	}
 
 	err = instances.Delete(dmsV1Client, d.Id()).ExtractErr()
 	if err != nil {
-		return fmt.Errorf("Error deleting SberCloud instance: %s", err)
+		return diag.Errorf("Error deleting SberCloud instance: %s", err)
 	}
 
 	// Wait for the instance to delete before moving on.
@@ -379,13 +452,13 @@ func resourceDmsInstancesV1Delete(d *schema.ResourceData, meta interface{}) erro
 		Target:     []string{"DELETED"},
 		Refresh:    DmsInstancesV1StateRefreshFunc(dmsV1Client, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
+		Delay:      dmsInstanceStateDelay,
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForStateContext(ctx)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"Error waiting for instance (%s) to delete: %s",
 			d.Id(), err)
 	}
@@ -395,6 +468,129 @@ func resourceDmsInstancesV1Delete(d *schema.ResourceData, meta interface{}) erro
 	return nil
 }
 
+// resourceDmsInstancesV1CustomizeDiff replaces the instance when the changes
+// can not be applied in place.
+func resourceDmsInstancesV1CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	// the storage space can only be expanded
+	if d.HasChange("storage_space") && d.NewValueKnown("storage_space") {
+		oldSpace, newSpace := d.GetChange("storage_space")
+		if newSpace.(int) < oldSpace.(int) {
+			if err := d.ForceNew("storage_space"); err != nil {
+				return err
+			}
+		}
+	}
+
+	// SSL is enabled on creation if the access user or the password is set, and can not be
+	// toggled later, while the password of an instance with SSL is reset in place
+	if d.HasChange("password") && d.NewValueKnown("password") {
+		oldPassword, newPassword := d.GetChange("password")
+		oldUser, newUser := d.GetChange("access_user")
+		oldSsl := oldUser.(string) != "" || oldPassword.(string) != ""
+		newSsl := newUser.(string) != "" || newPassword.(string) != ""
+		if oldSsl != newSsl {
+			if err := d.ForceNew("password"); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the bandwidth and the partitions of a Kafka instance are defined by its product,
+	// so they can only be changed in place together with the product, and are read
+	// from the new product unless they are specified
+	for _, key := range []string{"specification", "partition_num"} {
+		if !d.HasChange("product_id") && d.HasChange(key) {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+		if d.HasChange("product_id") && !d.HasChange(key) {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resizeDmsInstanceV1 expands the storage space or changes the product of the instance
+// and waits for the instance to be running again.
+func resizeDmsInstanceV1(ctx context.Context, d *schema.ResourceData, client *golangsdk.ServiceClient) error {
+	resizeOpts := make(map[string]interface{})
+	if d.HasChange("product_id") {
+		resizeOpts["new_spec_code"] = d.Get("product_id").(string)
+	}
+	if d.HasChange("storage_space") {
+		resizeOpts["new_storage_space"] = d.Get("storage_space").(int)
+	}
+	log.Printf("[DEBUG] Resize Options of dms instance (%s): %#v", d.Id(), resizeOpts)
+
+	_, err := client.Post(client.ServiceURL("instances", d.Id(), "extend"), resizeOpts, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 202, 204},
+	})
+	if err != nil {
+		return fmt.Errorf("Error resizing SberCloud dms instance (%s): %s", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"EXTENDING"},
+		Target:     []string{"RUNNING"},
+		Refresh:    DmsInstancesV1StateRefreshFunc(client, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      dmsInstanceStateDelay,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("Error waiting for instance (%s) to be resized: %s", d.Id(), err)
+	}
+
+	// the bandwidth and the partitions are given by the product rather than requested
+	result := instances.Get(client, d.Id())
+	v, err := result.Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving SberCloud dms instance (%s): %s", d.Id(), err)
+	}
+	partitionNum, err := extractDmsPartitionNum(result)
+	if err != nil {
+		return fmt.Errorf("Error extracting the partitions of dms instance (%s): %s", d.Id(), err)
+	}
+	if spec := d.Get("specification").(string); spec != "" && spec != v.Specification {
+		return fmt.Errorf("the product %s of dms instance (%s) has the specification %s instead of %s",
+			v.ProductID, d.Id(), v.Specification, spec)
+	}
+	if num := d.Get("partition_num").(int); num != 0 && num != partitionNum {
+		return fmt.Errorf("the product %s of dms instance (%s) has %d partitions instead of %d",
+			v.ProductID, d.Id(), partitionNum, num)
+	}
+	return nil
+}
+
+// extractDmsPartitionNum returns the partitions of a Kafka instance, which are
+// missing from the instance of golangsdk, and 0 for the other engines.
+func extractDmsPartitionNum(r instances.GetResult) (int, error) {
+	var instance struct {
+		PartitionNum interface{} `json:"partition_num"`
+	}
+	if err := r.ExtractInto(&instance); err != nil {
+		return 0, err
+	}
+
+	switch num := instance.PartitionNum.(type) {
+	case string:
+		if num == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(num)
+	case float64:
+		return int(num), nil
+	}
+	return 0, nil
+}
+
 func DmsInstancesV1StateRefreshFunc(client *golangsdk.ServiceClient, instanceID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		v, err := instances.Get(client, instanceID).Extract()
@@ -1,12 +1,22 @@
 package sbercloud
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/chnsz/golangsdk/openstack/dms/v1/instances"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 )
@@ -202,3 +212,565 @@ resource "sbercloud_dms_instance" "instance_1" {
   }
 }`, testAccDmsV1Instance_base(instanceName), instanceName)
 }
+
+// testDMSServer is a local stand-in for the DMS API, it keeps the instances
+// and their tags in memory and records the requests.
+type testDMSServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	instances map[string]map[string]interface{}
+	tags      map[string]map[string]string
+	passwords map[string]string
+	requests  []testDMSRequest
+	// the status of the new instances before they are read for the first time
+	createStatus string
+	// the status code which the tags API answers with, if it is set
+	tagsStatus int
+	// the attributes which the instances get from their new products on resize
+	products map[string]map[string]interface{}
+}
+
+type testDMSRequest struct {
+	method string
+	path   string
+	body   map[string]interface{}
+}
+
+func newTestDMSServer(t *testing.T) *testDMSServer {
+	s := &testDMSServer{
+		instances:    make(map[string]map[string]interface{}),
+		tags:         make(map[string]map[string]string),
+		passwords:    make(map[string]string),
+		createStatus: "RUNNING",
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, testDMSRequest{method: r.Method, path: r.URL.Path, body: body})
+
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case len(parts) >= 3 && parts[0] == "v1.0" && parts[2] == "instances":
+			s.serveInstances(w, r.Method, parts[3:], body)
+		case len(parts) >= 5 && parts[0] == "v2" && parts[4] == "tags":
+			s.serveTags(w, r.Method, parts[3], parts[5:], body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testDMSServer) serveInstances(w http.ResponseWriter, method string, path []string, body map[string]interface{}) {
+	if len(path) == 0 && method == http.MethodPost {
+		id := fmt.Sprintf("dms-instance-%d", len(s.instances)+1)
+		instance := map[string]interface{}{
+			"instance_id": id,
+			"status":      s.createStatus,
+			"port":        5672,
+			"user_id":     testIAMUserID,
+			"user_name":   testIAMUserName,
+		}
+		for key, value := range body {
+			if key != "password" && key != "access_user" {
+				instance[key] = value
+			}
+		}
+		s.instances[id] = instance
+		s.passwords[id], _ = body["password"].(string)
+		writeTestJSON(w, map[string]interface{}{"instance_id": id})
+		return
+	}
+
+	instance, ok := s.instances[path[0]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":"DMS.00404022","error_msg":"The instance does not exist."}`))
+		return
+	}
+
+	if len(path) > 1 && path[1] == "password" && method == http.MethodPut {
+		s.passwords[path[0]] = body["new_password"].(string)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if len(path) > 1 && path[1] == "extend" && method == http.MethodPost {
+		if space, ok := body["new_storage_space"]; ok {
+			instance["storage_space"] = space
+		}
+		if product, ok := body["new_spec_code"]; ok {
+			instance["product_id"] = product
+			for key, value := range s.products[product.(string)] {
+				instance[key] = value
+			}
+		}
+		writeTestJSON(w, map[string]interface{}{})
+		return
+	}
+
+	switch method {
+	case http.MethodGet:
+		writeTestJSON(w, instance)
+	case http.MethodPut:
+		for key, value := range body {
+			instance[key] = value
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(s.instances, path[0])
+		delete(s.tags, path[0])
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *testDMSServer) serveTags(w http.ResponseWriter, method, id string, path []string, body map[string]interface{}) {
+	if s.tagsStatus != 0 {
+		w.WriteHeader(s.tagsStatus)
+		_, _ = w.Write([]byte(`{"error_code":"DMS.00500000","error_msg":"The tags service is unavailable."}`))
+		return
+	}
+	if _, ok := s.instances[id]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if method == http.MethodGet {
+		tags := make([]map[string]string, 0, len(s.tags[id]))
+		for key, value := range s.tags[id] {
+			tags = append(tags, map[string]string{"key": key, "value": value})
+		}
+		writeTestJSON(w, map[string]interface{}{"tags": tags})
+		return
+	}
+
+	if s.tags[id] == nil {
+		s.tags[id] = make(map[string]string)
+	}
+	for _, raw := range body["tags"].([]interface{}) {
+		tag := raw.(map[string]interface{})
+		if body["action"] == "delete" {
+			delete(s.tags[id], tag["key"].(string))
+		} else {
+			s.tags[id][tag["key"].(string)], _ = tag["value"].(string)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setStatus changes the status of the instance which is returned by the API.
+func (s *testDMSServer) setStatus(id, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.instances[id]["status"] = status
+}
+
+func (s *testDMSServer) instance(id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	instance, ok := s.instances[id]
+	return instance, ok
+}
+
+func (s *testDMSServer) password(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.passwords[id]
+}
+
+func (s *testDMSServer) instanceTags(id string) map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tags[id]
+}
+
+// lastRequest returns the last request sent with method to a path ending with suffix.
+func (s *testDMSServer) lastRequest(method, suffix string) (testDMSRequest, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.requests) - 1; i >= 0; i-- {
+		if s.requests[i].method == method && strings.HasSuffix(s.requests[i].path, suffix) {
+			return s.requests[i], true
+		}
+	}
+	return testDMSRequest{}, false
+}
+
+// testDMSProviderMeta configures the provider against the IAM and DMS stand-ins.
+func testDMSProviderMeta(t *testing.T, dms *testDMSServer) interface{} {
+	stateDelay := dmsInstanceStateDelay
+	dmsInstanceStateDelay = 0
+	t.Cleanup(func() { dmsInstanceStateDelay = stateDelay })
+
+	iam := newTestIAMServer(t)
+	meta, err := configureProvider(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "automation-ak",
+		"secret_key":  "automation-sk",
+		"project_id":  testIAMProjectID,
+		"domain_id":   testIAMDomainID,
+		"max_retries": 0,
+		"endpoints": map[string]interface{}{
+			"iam":   iam.URL,
+			"dms":   dms.URL + "/",
+			"dmsv2": dms.URL + "/",
+		},
+	}), "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return meta
+}
+
+func testDMSInstanceData(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, ResourceDmsInstancesV1().Schema, testDMSInstanceConfig(raw))
+}
+
+// testDMSInstanceConfig returns the configuration of a RabbitMQ instance overridden by raw.
+func testDMSInstanceConfig(raw map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{
+		"name":              "dms-test",
+		"engine":            "rabbitmq",
+		"engine_version":    "3.7.17",
+		"storage_space":     100,
+		"storage_spec_code": "dms.physical.storage.normal",
+		"access_user":       "user",
+		"password":          "Dmstest@123",
+		"vpc_id":            "vpc-id",
+		"subnet_id":         "subnet-id",
+		"security_group_id": "secgroup-id",
+		"available_zones":   []interface{}{"ru-moscow-1a"},
+		"product_id":        "00300-30109-0--0",
+	}
+	for key, value := range raw {
+		config[key] = value
+	}
+	return config
+}
+
+// testUpdateDMSInstance plans and applies the configuration overridden by raw to the instance of d.
+func testUpdateDMSInstance(t *testing.T, meta interface{}, d *schema.ResourceData, raw map[string]interface{}) *schema.ResourceData {
+	r := ResourceDmsInstancesV1()
+	state := d.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(testDMSInstanceConfig(raw)), meta)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("expected the instance to be updated in place, got %#v", diff.Attributes)
+	}
+
+	state, diags := r.Apply(context.Background(), state, diff, meta)
+	expectNoWarnings(t, diags)
+	return r.Data(state)
+}
+
+func expectNoWarnings(t *testing.T, diags diag.Diagnostics) {
+	if len(diags) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestResourceDmsInstancesV1_lifecycle(t *testing.T) {
+	dms := newTestDMSServer(t)
+	meta := testDMSProviderMeta(t, dms)
+	ctx := context.Background()
+
+	d := testDMSInstanceData(t, map[string]interface{}{
+		"tags": map[string]interface{}{"owner": "terraform"},
+	})
+	expectNoWarnings(t, resourceDmsInstancesV1Create(ctx, d, meta))
+
+	if d.Id() != "dms-instance-1" || d.Get("status").(string) != "RUNNING" {
+		t.Fatalf("unexpected instance %q in status %q", d.Id(), d.Get("status"))
+	}
+	if d.Get("port").(string) != "5672" {
+		t.Fatalf("expected the port to be read, got %q", d.Get("port"))
+	}
+	req, _ := dms.lastRequest(http.MethodPost, "/instances")
+	if req.body["password"] != "Dmstest@123" || req.body["ssl_enable"] != true {
+		t.Fatalf("unexpected create request: %v", req.body)
+	}
+	if tags := d.Get("tags").(map[string]interface{}); tags["owner"] != "terraform" {
+		t.Fatalf("expected the tags to be read, got %v", tags)
+	}
+
+	// update the description and the tags
+	d = testUpdateDMSInstance(t, meta, d, map[string]interface{}{
+		"description": "updated",
+		"tags":        map[string]interface{}{"owner": "team-a"},
+	})
+
+	if instance, _ := dms.instance("dms-instance-1"); instance["description"] != "updated" {
+		t.Fatalf("expected the description to be updated, got %v", instance["description"])
+	}
+	if tags := dms.instanceTags("dms-instance-1"); len(tags) != 1 || tags["owner"] != "team-a" {
+		t.Fatalf("expected the tags to be updated, got %v", tags)
+	}
+
+	expectNoWarnings(t, resourceDmsInstancesV1Delete(ctx, d, meta))
+	if _, ok := dms.instance("dms-instance-1"); ok || d.Id() != "" {
+		t.Fatalf("expected the instance to be deleted")
+	}
+
+	// the instance which has been deleted outside of terraform is removed from the state
+	d.SetId("dms-instance-1")
+	expectNoWarnings(t, resourceDmsInstancesV1Read(ctx, d, meta))
+	if d.Id() != "" {
+		t.Fatalf("expected the instance to be removed from the state")
+	}
+}
+
+func TestResourceDmsInstancesV1_tagsWarning(t *testing.T) {
+	dms := newTestDMSServer(t)
+	dms.tagsStatus = http.StatusInternalServerError
+	meta := testDMSProviderMeta(t, dms)
+
+	d := testDMSInstanceData(t, map[string]interface{}{
+		"tags": map[string]interface{}{"owner": "terraform"},
+	})
+	diags := resourceDmsInstancesV1Create(context.Background(), d, meta)
+	if diags.HasError() {
+		t.Fatalf("expected the tag failures not to fail the creation: %v", diags)
+	}
+	if d.Id() != "dms-instance-1" {
+		t.Fatalf("expected the instance to be stored in the state, got %q", d.Id())
+	}
+
+	var summaries []string
+	for _, d := range diags {
+		if d.Severity == diag.Warning {
+			summaries = append(summaries, d.Summary)
+		}
+	}
+	expected := []string{"Error setting tags of the DMS instance", "Error fetching tags of the DMS instance"}
+	if !reflect.DeepEqual(summaries, expected) {
+		t.Fatalf("expected the warnings %v, got %v", expected, summaries)
+	}
+}
+
+func TestResourceDmsInstancesV1_createTimeout(t *testing.T) {
+	dms := newTestDMSServer(t)
+	dms.createStatus = "CREATING"
+	meta := testDMSProviderMeta(t, dms)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	d := testDMSInstanceData(t, nil)
+	diags := resourceDmsInstancesV1Create(ctx, d, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "to become ready") {
+		t.Fatalf("expected the wait to stop with the context, got %v", diags)
+	}
+}
+
+func TestResourceDmsInstancesV1_timeouts(t *testing.T) {
+	r := ResourceDmsInstancesV1()
+	if r.Timeouts == nil || r.Timeouts.Create == nil || r.Timeouts.Delete == nil {
+		t.Fatalf("expected the create and delete timeouts to be declared")
+	}
+	if err := r.InternalValidate(nil, true); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestResourceDmsInstancesV1_resize(t *testing.T) {
+	dms := newTestDMSServer(t)
+	meta := testDMSProviderMeta(t, dms)
+	ctx := context.Background()
+
+	d := testDMSInstanceData(t, nil)
+	expectNoWarnings(t, resourceDmsInstancesV1Create(ctx, d, meta))
+
+	d = testUpdateDMSInstance(t, meta, d, map[string]interface{}{
+		"storage_space": 200,
+		"product_id":    "00300-30110-0--0",
+	})
+
+	req, ok := dms.lastRequest(http.MethodPost, "/instances/dms-instance-1/extend")
+	if !ok {
+		t.Fatalf("expected the instance to be resized")
+	}
+	if req.body["new_storage_space"] != 200.0 || req.body["new_spec_code"] != "00300-30110-0--0" {
+		t.Fatalf("unexpected resize request: %v", req.body)
+	}
+	if d.Get("storage_space").(int) != 200 || d.Get("product_id").(string) != "00300-30110-0--0" {
+		t.Fatalf("expected the new size to be read, got %d and %s", d.Get("storage_space"), d.Get("product_id"))
+	}
+}
+
+func TestResourceDmsInstancesV1_resizeKafka(t *testing.T) {
+	dms := newTestDMSServer(t)
+	dms.products = map[string]map[string]interface{}{
+		"00300-30110-0--0": {"specification": "300MB", "partition_num": "900"},
+	}
+	meta := testDMSProviderMeta(t, dms)
+	ctx := context.Background()
+
+	kafka := map[string]interface{}{
+		"engine":        "kafka",
+		"specification": "100MB",
+		"partition_num": 300,
+	}
+	d := testDMSInstanceData(t, kafka)
+	expectNoWarnings(t, resourceDmsInstancesV1Create(ctx, d, meta))
+
+	// the partitions of the new product are read when they are not specified
+	d = testUpdateDMSInstance(t, meta, d, map[string]interface{}{
+		"engine":     "kafka",
+		"product_id": "00300-30110-0--0",
+	})
+	if d.Get("specification").(string) != "300MB" || d.Get("partition_num").(int) != 900 {
+		t.Fatalf("expected the product to be read, got %s and %d", d.Get("specification"), d.Get("partition_num"))
+	}
+
+	// the partitions which the product does not give fail the update
+	r := ResourceDmsInstancesV1()
+	d = testDMSInstanceData(t, kafka)
+	expectNoWarnings(t, resourceDmsInstancesV1Create(ctx, d, meta))
+	state := d.State()
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(testDMSInstanceConfig(map[string]interface{}{
+		"engine":        "kafka",
+		"specification": "300MB",
+		"partition_num": 1200,
+		"product_id":    "00300-30110-0--0",
+	})), meta)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	_, diags := r.Apply(ctx, state, diff, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "has 900 partitions instead of 1200") {
+		t.Fatalf("expected the partitions of the product to be checked, got %v", diags)
+	}
+}
+
+func TestResourceDmsInstancesV1_customizeDiff(t *testing.T) {
+	r := ResourceDmsInstancesV1()
+
+	cases := []struct {
+		name     string
+		state    map[string]interface{}
+		config   map[string]interface{}
+		forceNew []string
+		inPlace  []string
+	}{
+		{
+			name:    "storage expansion",
+			config:  map[string]interface{}{"storage_space": 200},
+			inPlace: []string{"storage_space"},
+		},
+		{
+			name:     "storage reduction",
+			config:   map[string]interface{}{"storage_space": 50},
+			forceNew: []string{"storage_space"},
+		},
+		{
+			name: "product upgrade",
+			config: map[string]interface{}{
+				"product_id":    "00300-30110-0--0",
+				"specification": "300MB",
+				"partition_num": 900,
+			},
+			inPlace: []string{"product_id", "specification", "partition_num"},
+		},
+		{
+			name:     "specification without product",
+			config:   map[string]interface{}{"specification": "300MB"},
+			forceNew: []string{"specification"},
+		},
+		{
+			name: "network",
+			config: map[string]interface{}{
+				"vpc_id":            "vpc-id-2",
+				"subnet_id":         "subnet-id-2",
+				"available_zones":   []interface{}{"ru-moscow-1b"},
+				"storage_spec_code": "dms.physical.storage.ultra",
+			},
+			forceNew: []string{"vpc_id", "subnet_id", "available_zones.0", "storage_spec_code"},
+		},
+		{
+			name:    "password rotation",
+			config:  map[string]interface{}{"password": "Dmstest@456"},
+			inPlace: []string{"password"},
+		},
+		{
+			name:     "access user",
+			config:   map[string]interface{}{"access_user": "admin"},
+			forceNew: []string{"access_user"},
+		},
+		{
+			name:     "enable SSL",
+			state:    map[string]interface{}{"access_user": "", "password": ""},
+			config:   map[string]interface{}{"password": "Dmstest@456"},
+			forceNew: []string{"password"},
+		},
+		{
+			name:     "disable SSL",
+			state:    map[string]interface{}{"access_user": ""},
+			config:   map[string]interface{}{"password": ""},
+			forceNew: []string{"password"},
+		},
+	}
+
+	for _, tc := range cases {
+		config := map[string]interface{}{
+			"engine":        "kafka",
+			"specification": "100MB",
+			"partition_num": 300,
+		}
+		for key, value := range tc.state {
+			config[key] = value
+		}
+		d := testDMSInstanceData(t, config)
+		d.SetId("dms-instance-1")
+		state := d.State()
+
+		for key, value := range tc.config {
+			config[key] = value
+		}
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(testDMSInstanceConfig(config)), nil)
+		if err != nil {
+			t.Fatalf("%s: err: %s", tc.name, err)
+		}
+		for _, key := range tc.forceNew {
+			if attr, ok := diff.Attributes[key]; !ok || !attr.RequiresNew {
+				t.Fatalf("%s: expected %s to replace the instance, got %#v", tc.name, key, diff.Attributes[key])
+			}
+		}
+		for _, key := range tc.inPlace {
+			if attr, ok := diff.Attributes[key]; !ok || attr.RequiresNew {
+				t.Fatalf("%s: expected %s to be changed in place, got %#v", tc.name, key, diff.Attributes[key])
+			}
+		}
+	}
+}
+
+func TestResourceDmsInstancesV1_resetPassword(t *testing.T) {
+	dms := newTestDMSServer(t)
+	meta := testDMSProviderMeta(t, dms)
+	ctx := context.Background()
+
+	d := testDMSInstanceData(t, nil)
+	expectNoWarnings(t, resourceDmsInstancesV1Create(ctx, d, meta))
+	if d.Get("instance_id").(string) != "dms-instance-1" || d.Get("user_name").(string) != testIAMUserName {
+		t.Fatalf("expected the computed attributes to be read, got %q and %q", d.Get("instance_id"), d.Get("user_name"))
+	}
+
+	d = testUpdateDMSInstance(t, meta, d, map[string]interface{}{
+		"password": "Dmstest@456",
+	})
+
+	if password := dms.password("dms-instance-1"); password != "Dmstest@456" {
+		t.Fatalf("expected the password to be reset, got %q", password)
+	}
+	if _, ok := dms.lastRequest(http.MethodPost, "/extend"); ok {
+		t.Fatalf("expected the instance not to be resized")
+	}
+}
@@ -0,0 +1,159 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/swr/v2/namespaces"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccSwrOrganizationPermissions_basic(t *testing.T) {
+	var permissions namespaces.Access
+	organizationName := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	userName1 := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	userName2 := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	resourceName := "sbercloud_swr_organization_permissions.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAdminOnly(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSWROrganizationPermissionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccswrOrganizationPermissions_basic(organizationName, userName1, userName2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSWROrganizationPermissionsExists(resourceName, &permissions),
+					resource.TestCheckResourceAttrPair(resourceName, "organization", "sbercloud_swr_organization.test", "name"),
+					resource.TestCheckResourceAttr(resourceName, "users.0.user_name", userName1),
+					resource.TestCheckResourceAttr(resourceName, "users.0.permission", "Read"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccswrOrganizationPermissions_update(organizationName, userName1, userName2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSWROrganizationPermissionsExists(resourceName, &permissions),
+					resource.TestCheckResourceAttrPair(resourceName, "organization", "sbercloud_swr_organization.test", "name"),
+					resource.TestCheckResourceAttr(resourceName, "users.0.user_name", userName1),
+					resource.TestCheckResourceAttr(resourceName, "users.0.permission", "Write"),
+					resource.TestCheckResourceAttr(resourceName, "users.1.user_name", userName2),
+					resource.TestCheckResourceAttr(resourceName, "users.1.permission", "Read"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSWROrganizationPermissionsDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.SwrV2Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud SWR client: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_swr_organization_permissions" {
+			continue
+		}
+		_, err := namespaces.GetAccess(client, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("SWR organization permissions (%s) still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckSWROrganizationPermissionsExists(n string, v *namespaces.Access) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource %s not found", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.SwrV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud SWR client: %s", err)
+		}
+		found, err := namespaces.GetAccess(client, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+		*v = *found
+		return nil
+	}
+}
+
+func testAccswrOrganizationPermissions_basic(organizationName, userName1, userName2 string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_swr_organization" "test" {
+  name = "%s"
+}
+
+resource "sbercloud_identity_user" "user_1" {
+  name     = "%s"
+  enabled  = true
+  password = "password12345!"
+}
+
+resource "sbercloud_swr_organization_permissions" "test" {
+  organization = sbercloud_swr_organization.test.name
+
+  users {
+    user_name  = sbercloud_identity_user.user_1.name
+    user_id    = sbercloud_identity_user.user_1.id
+    permission = "Read"
+  }
+}
+`, organizationName, userName1)
+}
+
+func testAccswrOrganizationPermissions_update(organizationName, userName1, userName2 string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_swr_organization" "test" {
+  name = "%s"
+}
+
+resource "sbercloud_identity_user" "user_1" {
+  name     = "%s"
+  enabled  = true
+  password = "password12345!"
+}
+
+resource "sbercloud_identity_user" "user_2" {
+  name     = "%s"
+  enabled  = true
+  password = "password12345!"
+}
+
+resource "sbercloud_swr_organization_permissions" "test" {
+  organization = sbercloud_swr_organization.test.name
+
+  users {
+    user_name  = sbercloud_identity_user.user_1.name
+    user_id    = sbercloud_identity_user.user_1.id
+    permission = "Write"
+  }
+
+  users {
+    user_name  = sbercloud_identity_user.user_2.name
+    user_id    = sbercloud_identity_user.user_2.id
+    permission = "Read"
+  }
+}
+`, organizationName, userName1, userName2)
+}
@@ -0,0 +1,92 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/swr/v2/namespaces"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccSWROrganization_basic(t *testing.T) {
+	var org namespaces.Namespace
+	rName := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	resourceName := "sbercloud_swr_organization.test"
+	loginServer := fmt.Sprintf("swr.%s.hc.sbercloud.ru", SBC_REGION_NAME)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSWROrganizationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSWROrganization_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSWROrganizationExists(resourceName, &org),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "permission", "Manage"),
+					resource.TestCheckResourceAttr(resourceName, "login_server", loginServer),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckSWROrganizationDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.SwrV2Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud SWR client: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_swr_organization" {
+			continue
+		}
+		_, err := namespaces.Get(client, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("SWR organization (%s) still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckSWROrganizationExists(n string, v *namespaces.Namespace) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource %s not found", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.SwrV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud SWR client: %s", err)
+		}
+		found, err := namespaces.Get(client, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+		*v = *found
+		return nil
+	}
+}
+
+func testAccSWROrganization_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_swr_organization" "test" {
+  name = "%s"
+}
+`, rName)
+}
@@ -0,0 +1,151 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/swr/v2/domains"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccSWRRepositorySharing_basic(t *testing.T) {
+	var domain domains.AccessDomain
+	rName := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	resourceName := "sbercloud_swr_repository_sharing.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckSWRSharingAccount(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSWRRepositorySharingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSWRRepositorySharing_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSWRRepositorySharingExists(resourceName, &domain),
+					resource.TestCheckResourceAttrPair(resourceName, "organization", "sbercloud_swr_organization.test", "name"),
+					resource.TestCheckResourceAttrPair(resourceName, "repository", "sbercloud_swr_repository.test", "name"),
+					resource.TestCheckResourceAttr(resourceName, "sharing_account", SBC_SWR_SHARING_ACCOUNT),
+					resource.TestCheckResourceAttr(resourceName, "deadline", "forever"),
+					resource.TestCheckResourceAttr(resourceName, "permission", "pull"),
+				),
+			},
+			{
+				Config: testAccSWRRepositorySharing_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSWRRepositorySharingExists(resourceName, &domain),
+					resource.TestCheckResourceAttrPair(resourceName, "organization", "sbercloud_swr_organization.test", "name"),
+					resource.TestCheckResourceAttrPair(resourceName, "repository", "sbercloud_swr_repository.test", "name"),
+					resource.TestCheckResourceAttr(resourceName, "sharing_account", SBC_SWR_SHARING_ACCOUNT),
+					resource.TestCheckResourceAttr(resourceName, "deadline", "2099-12-31"),
+					resource.TestCheckResourceAttr(resourceName, "permission", "pull"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccSWRRepositorySharingImportStateIdFunc(),
+			},
+		},
+	})
+}
+
+func testAccCheckSWRRepositorySharingDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.SwrV2Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud SWR client: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_swr_repository_sharing" {
+			continue
+		}
+		_, err := domains.Get(client, rs.Primary.Attributes["organization"],
+			rs.Primary.Attributes["repository"], rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("SWR repository sharing (%s) still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckSWRRepositorySharingExists(n string, v *domains.AccessDomain) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource %s not found", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.SwrV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud SWR client: %s", err)
+		}
+		found, err := domains.Get(client, rs.Primary.Attributes["organization"],
+			rs.Primary.Attributes["repository"], rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+		*v = *found
+		return nil
+	}
+}
+
+func testAccSWRRepositorySharingImportStateIdFunc() resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		var organization string
+		var repositoryID string
+		var sharingAccount string
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type == "sbercloud_swr_organization" {
+				organization = rs.Primary.Attributes["name"]
+			} else if rs.Type == "sbercloud_swr_repository" {
+				repositoryID = rs.Primary.ID
+			} else if rs.Type == "sbercloud_swr_repository_sharing" {
+				sharingAccount = rs.Primary.ID
+			}
+		}
+		if organization == "" || repositoryID == "" || sharingAccount == "" {
+			return "", fmt.Errorf("resource not found: %s/%s/%s", organization, repositoryID, sharingAccount)
+		}
+		return fmt.Sprintf("%s/%s/%s", organization, repositoryID, sharingAccount), nil
+	}
+}
+
+func testAccSWRRepositorySharing_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_swr_repository_sharing" "test" {
+  organization    = sbercloud_swr_organization.test.name
+  repository      = sbercloud_swr_repository.test.name
+  sharing_account = "%s"
+  permission      = "pull"
+  deadline        = "forever"
+}
+`, testAccSWRRepository_basic(rName), SBC_SWR_SHARING_ACCOUNT)
+}
+
+func testAccSWRRepositorySharing_update(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_swr_repository_sharing" "test" {
+  organization    = sbercloud_swr_organization.test.name
+  repository      = sbercloud_swr_repository.test.name
+  sharing_account = "%s"
+  permission      = "pull"
+  deadline        = "2099-12-31"
+}
+`, testAccSWRRepository_basic(rName), SBC_SWR_SHARING_ACCOUNT)
+}
@@ -0,0 +1,141 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/swr/v2/repositories"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccSWRRepository_basic(t *testing.T) {
+	var repo repositories.ImageRepository
+	rName := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	resourceName := "sbercloud_swr_repository.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSWRRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSWRRepository_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSWRRepositoryExists(resourceName, &repo),
+					resource.TestCheckResourceAttrPair(resourceName, "organization", "sbercloud_swr_organization.test", "name"),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "category", "linux"),
+					resource.TestCheckResourceAttr(resourceName, "is_public", "false"),
+				),
+			},
+			{
+				Config: testAccSWRRepository_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSWRRepositoryExists(resourceName, &repo),
+					resource.TestCheckResourceAttrPair(resourceName, "organization", "sbercloud_swr_organization.test", "name"),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "category", "windows"),
+					resource.TestCheckResourceAttr(resourceName, "is_public", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccSWRRepositoryImportStateIdFunc(),
+			},
+		},
+	})
+}
+
+func testAccCheckSWRRepositoryDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.SwrV2Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud SWR client: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_swr_repository" {
+			continue
+		}
+		_, err := repositories.Get(client, rs.Primary.Attributes["organization"], rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("SWR repository (%s) still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckSWRRepositoryExists(n string, v *repositories.ImageRepository) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Resource %s not found", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.SwrV2Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud SWR client: %s", err)
+		}
+		found, err := repositories.Get(client, rs.Primary.Attributes["organization"], rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+		*v = *found
+		return nil
+	}
+}
+
+func testAccSWRRepositoryImportStateIdFunc() resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		var organization string
+		var repositoryID string
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type == "sbercloud_swr_organization" {
+				organization = rs.Primary.Attributes["name"]
+			} else if rs.Type == "sbercloud_swr_repository" {
+				repositoryID = rs.Primary.ID
+			}
+		}
+		if organization == "" || repositoryID == "" {
+			return "", fmt.Errorf("resource not found: %s/%s", organization, repositoryID)
+		}
+		return fmt.Sprintf("%s/%s", organization, repositoryID), nil
+	}
+}
+
+func testAccSWRRepository_basic(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_swr_repository" "test" {
+  organization = sbercloud_swr_organization.test.name
+  name         = "%s"
+  description  = "Test repository"
+  category     = "linux"
+  is_public    = false
+}
+`, testAccSWROrganization_basic(rName), rName)
+}
+
+func testAccSWRRepository_update(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_swr_repository" "test" {
+  organization = sbercloud_swr_organization.test.name
+  name         = "%s"
+  description  = "Test repository"
+  category     = "windows"
+  is_public    = true
+}
+`, testAccSWROrganization_basic(rName), rName)
+}
@@ -0,0 +1,173 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	domains "github.com/chnsz/golangsdk/openstack/waf_hw/v1/premium_domains"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccWafDedicatedDomain_basic(t *testing.T) {
+	var domain domains.PremiumHost
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	domainName := fmt.Sprintf("tf-acc-test-%s.example.com", acctest.RandString(5))
+	resourceName := "sbercloud_waf_dedicated_domain.domain_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWafDedicatedDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafDedicatedDomain_basic(name, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafDedicatedDomainExists(resourceName, &domain),
+					resource.TestCheckResourceAttr(resourceName, "domain", domainName),
+					resource.TestCheckResourceAttr(resourceName, "proxy", "false"),
+					resource.TestCheckResourceAttr(resourceName, "server.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "server.0.client_protocol", "HTTPS"),
+					resource.TestCheckResourceAttr(resourceName, "server.0.server_protocol", "HTTP"),
+					resource.TestCheckResourceAttr(resourceName, "server.0.address", "192.168.0.10"),
+					resource.TestCheckResourceAttr(resourceName, "server.0.port", "8080"),
+					resource.TestCheckResourceAttr(resourceName, "server.0.type", "ipv4"),
+					resource.TestCheckResourceAttrPair(resourceName, "certificate_id",
+						"sbercloud_waf_certificate.certificate_1", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "policy_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "protocol"),
+				),
+			},
+			{
+				Config: testAccWafDedicatedDomain_update(name, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafDedicatedDomainExists(resourceName, &domain),
+					resource.TestCheckResourceAttr(resourceName, "proxy", "true"),
+					resource.TestCheckResourceAttr(resourceName, "protect_status", "0"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"keep_policy"},
+			},
+		},
+	})
+}
+
+func testAccCheckWafDedicatedDomainDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.WafDedicatedV1Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud WAF dedicated client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_waf_dedicated_domain" {
+			continue
+		}
+
+		_, err := domains.Get(client, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("WAF dedicated domain still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckWafDedicatedDomainExists(n string, domain *domains.PremiumHost) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.WafDedicatedV1Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud WAF dedicated client: %s", err)
+		}
+
+		found, err := domains.Get(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.Id != rs.Primary.ID {
+			return fmt.Errorf("WAF dedicated domain not found")
+		}
+
+		*domain = *found
+
+		return nil
+	}
+}
+
+func testAccWafDedicatedDomain_basic(name, domainName string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "sbercloud_waf_dedicated_domain" "domain_1" {
+  domain         = "%s"
+  certificate_id = sbercloud_waf_certificate.certificate_1.id
+  keep_policy    = false
+  proxy          = false
+
+  server {
+    client_protocol = "HTTPS"
+    server_protocol = "HTTP"
+    address         = "192.168.0.10"
+    port            = 8080
+    type            = "ipv4"
+    vpc_id          = sbercloud_vpc.vpc_1.id
+  }
+
+  depends_on = [
+    sbercloud_waf_dedicated_instance.instance_1
+  ]
+}
+`, testAccWafDedicatedInstance_conf(name), testAccWafCertificate_conf(name), domainName)
+}
+
+func testAccWafDedicatedDomain_update(name, domainName string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "sbercloud_waf_dedicated_domain" "domain_1" {
+  domain         = "%s"
+  certificate_id = sbercloud_waf_certificate.certificate_1.id
+  keep_policy    = false
+  proxy          = true
+  protect_status = 0
+
+  server {
+    client_protocol = "HTTPS"
+    server_protocol = "HTTP"
+    address         = "192.168.0.10"
+    port            = 8080
+    type            = "ipv4"
+    vpc_id          = sbercloud_vpc.vpc_1.id
+  }
+
+  depends_on = [
+    sbercloud_waf_dedicated_instance.instance_1
+  ]
+}
+`, testAccWafDedicatedInstance_conf(name), testAccWafCertificate_conf(name), domainName)
+}
@@ -0,0 +1,231 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	instances "github.com/chnsz/golangsdk/openstack/waf_hw/v1/premium_instances"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccWafDedicatedInstance_basic(t *testing.T) {
+	var instance instances.DedicatedInstance
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	resourceName := "sbercloud_waf_dedicated_instance.instance_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWafDedicatedInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafDedicatedInstance_conf(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafDedicatedInstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "cpu_architecture", "x86"),
+					resource.TestCheckResourceAttr(resourceName, "specification_code", "waf.instance.professional"),
+					resource.TestCheckResourceAttr(resourceName, "security_group.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "run_status", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "server_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "service_ip"),
+					resource.TestCheckResourceAttrPair(resourceName, "vpc_id", "sbercloud_vpc.vpc_1", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "subnet_id", "sbercloud_vpc_subnet.vpc_subnet_1", "id"),
+				),
+			},
+			{
+				Config: testAccWafDedicatedInstance_update(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafDedicatedInstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttr(resourceName, "name", name+"_update"),
+					resource.TestCheckResourceAttr(resourceName, "run_status", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccWafDedicatedInstance_group(t *testing.T) {
+	var instance instances.DedicatedInstance
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	resourceName := "sbercloud_waf_dedicated_instance.instance_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWafDedicatedInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafDedicatedInstance_group(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafDedicatedInstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttrPair(resourceName, "group_id",
+						"sbercloud_waf_instance_group.group_1", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckWafDedicatedInstanceDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.WafDedicatedV1Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud WAF dedicated client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_waf_dedicated_instance" {
+			continue
+		}
+
+		_, err := instances.GetInstance(client, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("WAF dedicated instance still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckWafDedicatedInstanceExists(n string, instance *instances.DedicatedInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.WafDedicatedV1Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud WAF dedicated client: %s", err)
+		}
+
+		found, err := instances.GetInstance(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.Id != rs.Primary.ID {
+			return fmt.Errorf("WAF dedicated instance not found")
+		}
+
+		*instance = *found
+
+		return nil
+	}
+}
+
+// testAccWafDedicatedInstance_base creates the network resources the dedicated instance is deployed in.
+func testAccWafDedicatedInstance_base(name string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc" "vpc_1" {
+  name = "%s"
+  cidr = "192.168.0.0/24"
+}
+
+resource "sbercloud_vpc_subnet" "vpc_subnet_1" {
+  name       = "%s"
+  cidr       = "192.168.0.0/24"
+  gateway_ip = "192.168.0.1"
+  vpc_id     = sbercloud_vpc.vpc_1.id
+}
+
+resource "sbercloud_networking_secgroup" "secgroup_1" {
+  name        = "%s"
+  description = "terraform security group acceptance test"
+}
+
+data "sbercloud_availability_zones" "zones" {}
+
+data "sbercloud_compute_flavors" "flavors" {
+  availability_zone = data.sbercloud_availability_zones.zones.names[0]
+  performance_type  = "normal"
+  cpu_core_count    = 2
+}
+`, name, name, name)
+}
+
+// testAccWafDedicatedInstance_conf is also used by the tests of the dedicated domains.
+func testAccWafDedicatedInstance_conf(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_waf_dedicated_instance" "instance_1" {
+  name               = "%s"
+  available_zone     = data.sbercloud_availability_zones.zones.names[0]
+  specification_code = "waf.instance.professional"
+  ecs_flavor         = data.sbercloud_compute_flavors.flavors.ids[0]
+  vpc_id             = sbercloud_vpc.vpc_1.id
+  subnet_id          = sbercloud_vpc_subnet.vpc_subnet_1.id
+
+  security_group = [
+    sbercloud_networking_secgroup.secgroup_1.id
+  ]
+}
+`, testAccWafDedicatedInstance_base(name), name)
+}
+
+func testAccWafDedicatedInstance_update(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_waf_dedicated_instance" "instance_1" {
+  name               = "%s_update"
+  available_zone     = data.sbercloud_availability_zones.zones.names[0]
+  specification_code = "waf.instance.professional"
+  ecs_flavor         = data.sbercloud_compute_flavors.flavors.ids[0]
+  vpc_id             = sbercloud_vpc.vpc_1.id
+  subnet_id          = sbercloud_vpc_subnet.vpc_subnet_1.id
+
+  security_group = [
+    sbercloud_networking_secgroup.secgroup_1.id
+  ]
+}
+`, testAccWafDedicatedInstance_base(name), name)
+}
+
+func testAccWafDedicatedInstance_group(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_waf_instance_group" "group_1" {
+  name   = "%s"
+  vpc_id = sbercloud_vpc.vpc_1.id
+}
+
+resource "sbercloud_waf_dedicated_instance" "instance_1" {
+  name               = "%s"
+  available_zone     = data.sbercloud_availability_zones.zones.names[0]
+  specification_code = "waf.instance.professional"
+  ecs_flavor         = data.sbercloud_compute_flavors.flavors.ids[0]
+  vpc_id             = sbercloud_vpc.vpc_1.id
+  subnet_id          = sbercloud_vpc_subnet.vpc_subnet_1.id
+  group_id           = sbercloud_waf_instance_group.group_1.id
+
+  security_group = [
+    sbercloud_networking_secgroup.secgroup_1.id
+  ]
+}
+`, testAccWafDedicatedInstance_base(name), name, name)
+}
@@ -0,0 +1,201 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/waf_hw/v1/domains"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccWafDomain_basic(t *testing.T) {
+	var domain domains.Domain
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	domainName := fmt.Sprintf("tf-acc-test-%s.example.com", acctest.RandString(5))
+	resourceName := "sbercloud_waf_domain.domain_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWafDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafDomain_basic(name, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafDomainExists(resourceName, &domain),
+					resource.TestCheckResourceAttr(resourceName, "domain", domainName),
+					resource.TestCheckResourceAttr(resourceName, "proxy", "false"),
+					resource.TestCheckResourceAttr(resourceName, "server.0.client_protocol", "HTTPS"),
+					resource.TestCheckResourceAttr(resourceName, "server.0.server_protocol", "HTTP"),
+					resource.TestCheckResourceAttr(resourceName, "server.0.port", "8080"),
+				),
+			},
+			{
+				Config: testAccWafDomain_update(name, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafDomainExists(resourceName, &domain),
+					resource.TestCheckResourceAttr(resourceName, "proxy", "true"),
+					resource.TestCheckResourceAttr(resourceName, "server.0.port", "8443"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"keep_policy"},
+			},
+		},
+	})
+}
+
+func TestAccWafDomain_policy(t *testing.T) {
+	var domain domains.Domain
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	domainName := fmt.Sprintf("tf-acc-test-%s.example.com", acctest.RandString(5))
+	resourceName := "sbercloud_waf_domain.domain_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWafDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafDomain_policy(name, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafDomainExists(resourceName, &domain),
+					resource.TestCheckResourceAttr(resourceName, "domain", domainName),
+					resource.TestCheckResourceAttrPair(resourceName, "policy_id",
+						"sbercloud_waf_policy.policy_1", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckWafDomainDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	wafClient, err := config.WafV1Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud WAF client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_waf_domain" {
+			continue
+		}
+
+		_, err := domains.Get(wafClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("WAF domain still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckWafDomainExists(n string, domain *domains.Domain) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		wafClient, err := config.WafV1Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud WAF client: %s", err)
+		}
+
+		found, err := domains.Get(wafClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.Id != rs.Primary.ID {
+			return fmt.Errorf("WAF domain not found")
+		}
+
+		*domain = *found
+
+		return nil
+	}
+}
+
+func testAccWafDomain_basic(name, domainName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_waf_domain" "domain_1" {
+  domain           = "%s"
+  certificate_id   = sbercloud_waf_certificate.certificate_1.id
+  certificate_name = sbercloud_waf_certificate.certificate_1.name
+  keep_policy      = false
+  proxy            = false
+
+  server {
+    client_protocol = "HTTPS"
+    server_protocol = "HTTP"
+    address         = "119.8.0.14"
+    port            = 8080
+  }
+}
+`, testAccWafCertificate_conf(name), domainName)
+}
+
+func testAccWafDomain_update(name, domainName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_waf_domain" "domain_1" {
+  domain           = "%s"
+  certificate_id   = sbercloud_waf_certificate.certificate_1.id
+  certificate_name = sbercloud_waf_certificate.certificate_1.name
+  keep_policy      = false
+  proxy            = true
+
+  server {
+    client_protocol = "HTTPS"
+    server_protocol = "HTTP"
+    address         = "119.8.0.14"
+    port            = 8443
+  }
+}
+`, testAccWafCertificate_conf(name), domainName)
+}
+
+func testAccWafDomain_policy(name, domainName string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "sbercloud_waf_domain" "domain_1" {
+  domain           = "%s"
+  certificate_id   = sbercloud_waf_certificate.certificate_1.id
+  certificate_name = sbercloud_waf_certificate.certificate_1.name
+  policy_id        = sbercloud_waf_policy.policy_1.id
+  proxy            = true
+
+  server {
+    client_protocol = "HTTPS"
+    server_protocol = "HTTP"
+    address         = "119.8.0.14"
+    port            = 8080
+  }
+}
+`, testAccWafCertificate_conf(name), testAccWafPolicy_conf(name), domainName)
+}
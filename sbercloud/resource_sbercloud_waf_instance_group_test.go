@@ -0,0 +1,110 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/waf_hw/v1/pools"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccWafInstanceGroup_basic(t *testing.T) {
+	var group pools.Pool
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	resourceName := "sbercloud_waf_instance_group.group_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWafInstanceGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafInstanceGroup_conf(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafInstanceGroupExists(resourceName, &group),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttrPair(resourceName, "vpc_id", "sbercloud_vpc.vpc_1", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckWafInstanceGroupDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	client, err := config.WafDedicatedV1Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud WAF dedicated client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_waf_instance_group" {
+			continue
+		}
+
+		_, err := pools.Get(client, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("WAF instance group still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckWafInstanceGroupExists(n string, group *pools.Pool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		client, err := config.WafDedicatedV1Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud WAF dedicated client: %s", err)
+		}
+
+		found, err := pools.Get(client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("WAF instance group not found")
+		}
+
+		*group = *found
+
+		return nil
+	}
+}
+
+func testAccWafInstanceGroup_conf(name string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_vpc" "vpc_1" {
+  name = "%s"
+  cidr = "192.168.0.0/24"
+}
+
+resource "sbercloud_waf_instance_group" "group_1" {
+  name   = "%s"
+  vpc_id = sbercloud_vpc.vpc_1.id
+}
+`, name, name)
+}
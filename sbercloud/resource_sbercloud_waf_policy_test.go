@@ -0,0 +1,126 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/waf_hw/v1/policies"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccWafPolicy_basic(t *testing.T) {
+	var policy policies.Policy
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	resourceName := "sbercloud_waf_policy.policy_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWafPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafPolicy_conf(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafPolicyExists(resourceName, &policy),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "level", "1"),
+					resource.TestCheckResourceAttr(resourceName, "full_detection", "false"),
+				),
+			},
+			{
+				Config: testAccWafPolicy_update(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafPolicyExists(resourceName, &policy),
+					resource.TestCheckResourceAttr(resourceName, "name", name+"_update"),
+					resource.TestCheckResourceAttr(resourceName, "protection_mode", "block"),
+					resource.TestCheckResourceAttr(resourceName, "level", "3"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckWafPolicyDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	wafClient, err := config.WafV1Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud WAF client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_waf_policy" {
+			continue
+		}
+
+		_, err := policies.Get(wafClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("WAF policy still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckWafPolicyExists(n string, policy *policies.Policy) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		wafClient, err := config.WafV1Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud WAF client: %s", err)
+		}
+
+		found, err := policies.Get(wafClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.Id != rs.Primary.ID {
+			return fmt.Errorf("WAF policy not found")
+		}
+
+		*policy = *found
+
+		return nil
+	}
+}
+
+// testAccWafPolicy_conf is also used by the tests of the WAF rules.
+func testAccWafPolicy_conf(name string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_waf_policy" "policy_1" {
+  name  = "%s"
+  level = 1
+}
+`, name)
+}
+
+func testAccWafPolicy_update(name string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_waf_policy" "policy_1" {
+  name            = "%s_update"
+  protection_mode = "block"
+  level           = 3
+}
+`, name)
+}
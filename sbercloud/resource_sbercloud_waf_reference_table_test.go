@@ -0,0 +1,138 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/chnsz/golangsdk/openstack/waf_hw/v1/valuelists"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccWafReferenceTable_basic(t *testing.T) {
+	var table valuelists.WafValueList
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	resourceName := "sbercloud_waf_reference_table.table_1"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWafReferenceTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafReferenceTable_conf(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafReferenceTableExists(resourceName, &table),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "description", "terraform acceptance test"),
+					resource.TestCheckResourceAttr(resourceName, "type", "url"),
+					resource.TestCheckResourceAttr(resourceName, "conditions.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "creation_time"),
+				),
+			},
+			{
+				Config: testAccWafReferenceTable_update(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafReferenceTableExists(resourceName, &table),
+					resource.TestCheckResourceAttr(resourceName, "name", name+"_update"),
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					resource.TestCheckResourceAttr(resourceName, "conditions.#", "3"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckWafReferenceTableDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	wafClient, err := config.WafV1Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud WAF client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_waf_reference_table" {
+			continue
+		}
+
+		_, err := valuelists.Get(wafClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("WAF reference table still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckWafReferenceTableExists(n string, table *valuelists.WafValueList) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		wafClient, err := config.WafV1Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud WAF client: %s", err)
+		}
+
+		found, err := valuelists.Get(wafClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if found.Id != rs.Primary.ID {
+			return fmt.Errorf("WAF reference table not found")
+		}
+
+		*table = *found
+
+		return nil
+	}
+}
+
+func testAccWafReferenceTable_conf(name string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_waf_reference_table" "table_1" {
+  name        = "%s"
+  type        = "url"
+  description = "terraform acceptance test"
+
+  conditions = [
+    "/admin",
+    "/manage",
+  ]
+}
+`, name)
+}
+
+func testAccWafReferenceTable_update(name string) string {
+	return fmt.Sprintf(`
+resource "sbercloud_waf_reference_table" "table_1" {
+  name = "%s_update"
+  type = "url"
+
+  conditions = [
+    "/admin",
+    "/bill",
+    "/sql",
+  ]
+}
+`, name)
+}
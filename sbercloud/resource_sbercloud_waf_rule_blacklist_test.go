@@ -0,0 +1,169 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	rules "github.com/chnsz/golangsdk/openstack/waf_hw/v1/whiteblackip_rules"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccWafRuleBlacklist_basic(t *testing.T) {
+	var rule rules.WhiteBlackIP
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	resourceName1 := "sbercloud_waf_rule_blacklist.rule_1"
+	resourceName2 := "sbercloud_waf_rule_blacklist.rule_2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWafRuleBlacklistDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafRuleBlacklist_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafRuleBlacklistExists(resourceName1, &rule),
+					resource.TestCheckResourceAttr(resourceName1, "ip_address", "192.168.0.0/24"),
+					resource.TestCheckResourceAttr(resourceName1, "action", "0"),
+					testAccCheckWafRuleBlacklistExists(resourceName2, &rule),
+					resource.TestCheckResourceAttr(resourceName2, "ip_address", "192.165.0.0/24"),
+					resource.TestCheckResourceAttr(resourceName2, "action", "1"),
+				),
+			},
+			{
+				Config: testAccWafRuleBlacklist_update(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafRuleBlacklistExists(resourceName1, &rule),
+					resource.TestCheckResourceAttr(resourceName1, "ip_address", "192.168.0.125"),
+					resource.TestCheckResourceAttr(resourceName1, "action", "2"),
+					testAccCheckWafRuleBlacklistExists(resourceName2, &rule),
+					resource.TestCheckResourceAttr(resourceName2, "ip_address", "192.150.0.0/24"),
+					resource.TestCheckResourceAttr(resourceName2, "action", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName1,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccWafRuleImportStateIdFunc(resourceName1),
+			},
+		},
+	})
+}
+
+func testAccCheckWafRuleBlacklistDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	wafClient, err := config.WafV1Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud WAF client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_waf_rule_blacklist" {
+			continue
+		}
+
+		policyID := rs.Primary.Attributes["policy_id"]
+		_, err := rules.Get(wafClient, policyID, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("WAF blacklist rule still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckWafRuleBlacklistExists(n string, rule *rules.WhiteBlackIP) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		wafClient, err := config.WafV1Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud WAF client: %s", err)
+		}
+
+		policyID := rs.Primary.Attributes["policy_id"]
+		found, err := rules.Get(wafClient, policyID, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.Id != rs.Primary.ID {
+			return fmt.Errorf("WAF blacklist rule not found")
+		}
+
+		*rule = *found
+
+		return nil
+	}
+}
+
+// testAccWafRuleImportStateIdFunc returns the <policy id>/<rule id> import ID of the WAF rules,
+// which belong to the sbercloud_waf_policy.policy_1 resource.
+func testAccWafRuleImportStateIdFunc(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		policy, ok := s.RootModule().Resources["sbercloud_waf_policy.policy_1"]
+		if !ok {
+			return "", fmt.Errorf("WAF policy not found")
+		}
+		rule, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("WAF rule not found")
+		}
+
+		if policy.Primary.ID == "" || rule.Primary.ID == "" {
+			return "", fmt.Errorf("resource not found: %s/%s", policy.Primary.ID, rule.Primary.ID)
+		}
+		return fmt.Sprintf("%s/%s", policy.Primary.ID, rule.Primary.ID), nil
+	}
+}
+
+func testAccWafRuleBlacklist_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_waf_rule_blacklist" "rule_1" {
+  policy_id  = sbercloud_waf_policy.policy_1.id
+  ip_address = "192.168.0.0/24"
+}
+
+resource "sbercloud_waf_rule_blacklist" "rule_2" {
+  policy_id  = sbercloud_waf_policy.policy_1.id
+  ip_address = "192.165.0.0/24"
+  action     = 1
+}
+`, testAccWafPolicy_conf(name))
+}
+
+func testAccWafRuleBlacklist_update(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_waf_rule_blacklist" "rule_1" {
+  policy_id  = sbercloud_waf_policy.policy_1.id
+  ip_address = "192.168.0.125"
+  action     = 2
+}
+
+resource "sbercloud_waf_rule_blacklist" "rule_2" {
+  policy_id  = sbercloud_waf_policy.policy_1.id
+  ip_address = "192.150.0.0/24"
+  action     = 0
+}
+`, testAccWafPolicy_conf(name))
+}
@@ -0,0 +1,154 @@
+package sbercloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	rules "github.com/chnsz/golangsdk/openstack/waf_hw/v1/datamasking_rules"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func TestAccWafRuleDataMasking_basic(t *testing.T) {
+	var rule rules.DataMasking
+	name := fmt.Sprintf("tf_acc_test_%s", acctest.RandString(5))
+	resourceName1 := "sbercloud_waf_rule_data_masking.rule_1"
+	resourceName2 := "sbercloud_waf_rule_data_masking.rule_2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckWaf(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWafRuleDataMaskingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWafRuleDataMasking_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafRuleDataMaskingExists(resourceName1, &rule),
+					resource.TestCheckResourceAttr(resourceName1, "path", "/login"),
+					resource.TestCheckResourceAttr(resourceName1, "field", "params"),
+					resource.TestCheckResourceAttr(resourceName1, "subfield", "password"),
+					testAccCheckWafRuleDataMaskingExists(resourceName2, &rule),
+					resource.TestCheckResourceAttr(resourceName2, "field", "header"),
+				),
+			},
+			{
+				Config: testAccWafRuleDataMasking_update(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWafRuleDataMaskingExists(resourceName1, &rule),
+					resource.TestCheckResourceAttr(resourceName1, "path", "/login_new"),
+					resource.TestCheckResourceAttr(resourceName1, "field", "params"),
+					resource.TestCheckResourceAttr(resourceName1, "subfield", "secret"),
+					testAccCheckWafRuleDataMaskingExists(resourceName2, &rule),
+					resource.TestCheckResourceAttr(resourceName2, "field", "cookie"),
+				),
+			},
+			{
+				ResourceName:      resourceName1,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccWafRuleImportStateIdFunc(resourceName1),
+			},
+		},
+	})
+}
+
+func testAccCheckWafRuleDataMaskingDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*config.Config)
+	wafClient, err := config.WafV1Client(SBC_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Error creating SberCloud WAF client: %s", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sbercloud_waf_rule_data_masking" {
+			continue
+		}
+
+		policyID := rs.Primary.Attributes["policy_id"]
+		_, err := rules.Get(wafClient, policyID, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("WAF data masking rule still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckWafRuleDataMaskingExists(n string, rule *rules.DataMasking) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*config.Config)
+		wafClient, err := config.WafV1Client(SBC_REGION_NAME)
+		if err != nil {
+			return fmt.Errorf("Error creating SberCloud WAF client: %s", err)
+		}
+
+		policyID := rs.Primary.Attributes["policy_id"]
+		found, err := rules.Get(wafClient, policyID, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.Id != rs.Primary.ID {
+			return fmt.Errorf("WAF data masking rule not found")
+		}
+
+		*rule = *found
+
+		return nil
+	}
+}
+
+func testAccWafRuleDataMasking_basic(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_waf_rule_data_masking" "rule_1" {
+  policy_id = sbercloud_waf_policy.policy_1.id
+  path      = "/login"
+  field     = "params"
+  subfield  = "password"
+}
+
+resource "sbercloud_waf_rule_data_masking" "rule_2" {
+  policy_id = sbercloud_waf_policy.policy_1.id
+  path      = "/login"
+  field     = "header"
+  subfield  = "password"
+}
+`, testAccWafPolicy_conf(name))
+}
+
+func testAccWafRuleDataMasking_update(name string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "sbercloud_waf_rule_data_masking" "rule_1" {
+  policy_id = sbercloud_waf_policy.policy_1.id
+  path      = "/login_new"
+  field     = "params"
+  subfield  = "secret"
+}
+
+resource "sbercloud_waf_rule_data_masking" "rule_2" {
+  policy_id = sbercloud_waf_policy.policy_1.id
+  path      = "/login"
+  field     = "cookie"
+  subfield  = "secret"
+}
+`, testAccWafPolicy_conf(name))
+}
@@ -0,0 +1,337 @@
+package sbercloud
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Most of the resources and data sources are shared with the huaweicloud provider,
+// the wrappers below add the provider-level behaviors of SberCloud to them
+// without forking every resource.
+
+// providerSettings holds the settings of the provider which are applied by the wrapped
+// resources, as the configuration of the huaweicloud provider has no fields for them.
+type providerSettings struct {
+	defaultTags map[string]interface{}
+	ignoreTags  *ignoreTags
+	checkQuotas bool
+}
+
+// ignoreTags holds the tags which are managed outside of Terraform.
+type ignoreTags struct {
+	keys     map[string]bool
+	prefixes []string
+}
+
+func (t *ignoreTags) ignored(key string) bool {
+	if t == nil {
+		return false
+	}
+	if t.keys[key] {
+		return true
+	}
+	for _, prefix := range t.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandProviderSettings returns the settings of the provider configuration.
+func expandProviderSettings(d *schema.ResourceData) providerSettings {
+	settings := providerSettings{
+		checkQuotas: d.Get("check_quotas").(bool),
+	}
+
+	if v, ok := d.GetOk("default_tags.0.tags"); ok {
+		settings.defaultTags = v.(map[string]interface{})
+	}
+
+	if raw := d.Get("ignore_tags").([]interface{}); len(raw) > 0 && raw[0] != nil {
+		block := raw[0].(map[string]interface{})
+		settings.ignoreTags = &ignoreTags{keys: make(map[string]bool)}
+		for _, key := range block["keys"].(*schema.Set).List() {
+			settings.ignoreTags.keys[key.(string)] = true
+		}
+		for _, prefix := range block["key_prefixes"].(*schema.Set).List() {
+			settings.ignoreTags.prefixes = append(settings.ignoreTags.prefixes, prefix.(string))
+		}
+	}
+	return settings
+}
+
+// wrapResources applies the provider-level behaviors to all resources.
+func wrapResources(provider *schema.Provider, settings *providerSettings) {
+	for name, r := range provider.ResourcesMap {
+		wrapResourceTags(r, settings)
+		if prePaid, ok := prePaidResources[name]; ok {
+			wrapResourcePrePaid(r, prePaid)
+		}
+		if check, ok := quotaChecks[name]; ok {
+			wrapResourceQuotaCheck(r, check, settings)
+		}
+	}
+}
+
+// wrapResourceTags merges the provider default_tags into the tags of the resources with tags,
+// the tags of the resource take precedence. The tags which are read are all tags of the resource
+// except for the tags of the provider ignore_tags, so the ignored tags are neither reported as
+// a change nor removed on update, as the resources only remove the tags which were known before.
+// The computed tags_all holds the tags which are applied to the resource.
+func wrapResourceTags(r *schema.Resource, settings *providerSettings) {
+	if !isTaggableResource(r) {
+		return
+	}
+	if _, ok := r.Schema["tags_all"]; ok {
+		// the resource is registered under several names and has been wrapped already
+		return
+	}
+
+	// the schema of the huaweicloud provider may be shared with other resources
+	tags := *r.Schema["tags"]
+	suppress := tags.DiffSuppressFunc
+	tags.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+		if suppress != nil && suppress(k, old, new, d) {
+			return true
+		}
+		return settings.suppressTagsDiff(k, new, d)
+	}
+	r.Schema["tags"] = &tags
+	r.Schema["tags_all"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if err := diffResourceTags(d, settings); err != nil {
+			return err
+		}
+
+		if customizeDiff != nil {
+			return customizeDiff(ctx, d, meta)
+		}
+		return nil
+	}
+
+	// the functions are wrapped in their own variant, so they keep their timeouts
+	if fn := r.Create; fn != nil {
+		r.Create = legacyTagsFunc(fn, settings, true)
+	}
+	if fn := r.CreateContext; fn != nil {
+		r.CreateContext = contextTagsFunc(fn, settings, true)
+	}
+	if fn := r.Read; fn != nil {
+		r.Read = legacyTagsFunc(fn, settings, false)
+	}
+	if fn := r.ReadContext; fn != nil {
+		r.ReadContext = contextTagsFunc(fn, settings, false)
+	}
+	if fn := r.Update; fn != nil {
+		r.Update = legacyUpdateTagsFunc(fn, r.Schema, settings)
+	}
+	if fn := r.UpdateContext; fn != nil {
+		r.UpdateContext = contextUpdateTagsFunc(fn, r.Schema, settings)
+	}
+}
+
+// isTaggableResource returns true if the resource has configurable key/value tags.
+func isTaggableResource(r *schema.Resource) bool {
+	s, ok := r.Schema["tags"]
+	return ok && s.Type == schema.TypeMap && (s.Optional || s.Required)
+}
+
+// suppressTagsDiff suppresses the difference between the tags in the state, which include
+// the default tags, and the configured tags, unless the merged tags differ from the state.
+func (s *providerSettings) suppressTagsDiff(k, new string, d *schema.ResourceData) bool {
+	if len(s.defaultTags) == 0 || (k == "tags.%" && new == "") {
+		// there are no default tags, or the configured tags are not known yet
+		return false
+	}
+
+	old, configured := d.GetChange("tags")
+	if reflect.DeepEqual(configured, old) {
+		// the tags of the state are read when the tags are not configured,
+		// as the configured tags differ from the state
+		configured = map[string]interface{}{}
+	}
+	return reflect.DeepEqual(s.mergeTags(configured.(map[string]interface{})), old)
+}
+
+// diffResourceTags plans the tags which are applied to the resource.
+func diffResourceTags(d *schema.ResourceDiff, settings *providerSettings) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	// the planned tags are the merged tags when their difference is suppressed
+	if tags := settings.mergeTags(d.Get("tags").(map[string]interface{})); !reflect.DeepEqual(tags, d.Get("tags_all")) {
+		return d.SetNew("tags_all", tags)
+	}
+	return nil
+}
+
+// legacyTagsFunc returns fn with the tags of the provider settings, the tags are merged
+// before fn is called if merge is true, and the tags which were read are filtered after.
+func legacyTagsFunc(fn func(*schema.ResourceData, interface{}) error, settings *providerSettings,
+	merge bool) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		if merge {
+			if err := d.Set("tags", settings.mergeTags(d.Get("tags").(map[string]interface{}))); err != nil {
+				return err
+			}
+		}
+		if err := fn(d, meta); err != nil {
+			return err
+		}
+		return settings.readTags(d)
+	}
+}
+
+// contextTagsFunc is legacyTagsFunc for the context-aware functions.
+func contextTagsFunc(fn contextFunc, settings *providerSettings, merge bool) contextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if merge {
+			if err := d.Set("tags", settings.mergeTags(d.Get("tags").(map[string]interface{}))); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		diags := fn(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, diag.FromErr(settings.readTags(d))...)
+	}
+}
+
+// legacyUpdateTagsFunc returns the update function fn which applies the merged tags to the resource.
+func legacyUpdateTagsFunc(fn func(*schema.ResourceData, interface{}) error, m map[string]*schema.Schema,
+	settings *providerSettings) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		applied := d.Get("tags").(map[string]interface{})
+		if err := fn(d, meta); err != nil {
+			return err
+		}
+
+		data, err := updateTagsData(m, d, applied, settings.mergeTags(applied))
+		if err != nil {
+			return err
+		}
+		if data != nil {
+			if err := fn(data, meta); err != nil {
+				return err
+			}
+			if err := d.Set("tags", data.Get("tags")); err != nil {
+				return err
+			}
+		}
+		return settings.readTags(d)
+	}
+}
+
+// contextUpdateTagsFunc is legacyUpdateTagsFunc for the context-aware functions.
+func contextUpdateTagsFunc(fn contextFunc, m map[string]*schema.Schema, settings *providerSettings) contextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		applied := d.Get("tags").(map[string]interface{})
+		diags := fn(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+
+		data, err := updateTagsData(m, d, applied, settings.mergeTags(applied))
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		if data != nil {
+			diags = append(diags, fn(ctx, data, meta)...)
+			if diags.HasError() {
+				return diags
+			}
+			diags = append(diags, diag.FromErr(d.Set("tags", data.Get("tags")))...)
+		}
+		return append(diags, diag.FromErr(settings.readTags(d))...)
+	}
+}
+
+// updateTagsData returns the data to update the tags of the resource from the applied tags
+// to the merged tags, or nil if they are the same. The resources update their tags from the
+// tags of the state to the configured tags, which do not include the default tags, so the
+// default tags are applied with a second update of the tags only.
+func updateTagsData(m map[string]*schema.Schema, d *schema.ResourceData, applied,
+	tags map[string]interface{}) (*schema.ResourceData, error) {
+	state := d.State()
+	if state == nil || reflect.DeepEqual(applied, tags) {
+		return nil, nil
+	}
+
+	for k := range state.Attributes {
+		if strings.HasPrefix(k, "tags.") {
+			delete(state.Attributes, k)
+		}
+	}
+	state.Attributes["tags.%"] = strconv.Itoa(len(applied))
+	for k, v := range applied {
+		state.Attributes["tags."+k] = v.(string)
+	}
+
+	diff := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"tags.%": {Old: strconv.Itoa(len(applied)), New: strconv.Itoa(len(tags))},
+		},
+	}
+	for k, v := range applied {
+		if _, ok := tags[k]; !ok {
+			diff.Attributes["tags."+k] = &terraform.ResourceAttrDiff{Old: v.(string), NewRemoved: true}
+		}
+	}
+	for k, v := range tags {
+		if old, ok := applied[k]; !ok || old != v {
+			attr := &terraform.ResourceAttrDiff{New: v.(string)}
+			if ok {
+				attr.Old = old.(string)
+			}
+			diff.Attributes["tags."+k] = attr
+		}
+	}
+	return schema.InternalMap(m).Data(state, diff)
+}
+
+// mergeTags returns the default tags merged with the tags of the resource.
+func (s *providerSettings) mergeTags(tags map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for k, v := range s.defaultTags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
+
+// readTags removes the ignored tags from the tags which were read, and sets tags_all.
+func (s *providerSettings) readTags(d *schema.ResourceData) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	tags := make(map[string]interface{})
+	for k, v := range d.Get("tags").(map[string]interface{}) {
+		if !s.ignoreTags.ignored(k) {
+			tags[k] = v
+		}
+	}
+	if err := d.Set("tags", tags); err != nil {
+		return err
+	}
+	return d.Set("tags_all", tags)
+}
+
+type contextFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
@@ -0,0 +1,278 @@
+package sbercloud
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestWrapResourceTags_defaultTags(t *testing.T) {
+	cloud := newTestTagsCloud()
+	settings := &providerSettings{
+		defaultTags: map[string]interface{}{"owner": "team-a", "env": "prod"},
+	}
+	r := cloud.resource(settings)
+
+	config := map[string]interface{}{
+		"name": "instance-1",
+		"tags": map[string]interface{}{"app": "web", "env": "test"},
+	}
+
+	// create
+	state := testApplyTags(t, r, nil, config)
+	expectStateTags(t, r, state, "tags", map[string]string{"app": "web", "env": "test", "owner": "team-a"})
+	expectStateTags(t, r, state, "tags_all", map[string]string{"app": "web", "env": "test", "owner": "team-a"})
+	expectTagsMap(t, cloud.tags("instance-1"), map[string]string{"app": "web", "env": "test", "owner": "team-a"})
+
+	// refresh and plan without changes
+	state = testRefreshTags(t, r, state)
+	if diff := testPlanTags(t, r, state, config); !diff.Empty() {
+		t.Fatalf("expected an empty plan, got %#v", diff.Attributes)
+	}
+
+	// change the default tags
+	settings.defaultTags = map[string]interface{}{"owner": "team-b"}
+	diff := testPlanTags(t, r, state, config)
+	if attr := diff.Attributes["tags_all.owner"]; attr == nil || attr.New != "team-b" {
+		t.Fatalf("expected tags_all to be planned with the new default tags, got %#v", diff.Attributes)
+	}
+	state = testApplyTags(t, r, state, config)
+	expectStateTags(t, r, state, "tags_all", map[string]string{"app": "web", "env": "test", "owner": "team-b"})
+	expectTagsMap(t, cloud.tags("instance-1"), map[string]string{"app": "web", "env": "test", "owner": "team-b"})
+
+	// remove the tags of the resource
+	config = map[string]interface{}{"name": "instance-1"}
+	state = testApplyTags(t, r, state, config)
+	expectStateTags(t, r, state, "tags", map[string]string{"owner": "team-b"})
+	expectTagsMap(t, cloud.tags("instance-1"), map[string]string{"owner": "team-b"})
+	if diff := testPlanTags(t, r, state, config); !diff.Empty() {
+		t.Fatalf("expected an empty plan, got %#v", diff.Attributes)
+	}
+}
+
+func TestWrapResourceTags_import(t *testing.T) {
+	cloud := newTestTagsCloud()
+	cloud.setTags("instance-1", map[string]string{"app": "web", "owner": "team-a"})
+	r := cloud.resource(&providerSettings{
+		defaultTags: map[string]interface{}{"owner": "team-a"},
+	})
+
+	state := testRefreshTags(t, r, &terraform.InstanceState{ID: "instance-1"})
+	expectStateTags(t, r, state, "tags_all", map[string]string{"app": "web", "owner": "team-a"})
+	if diff := testPlanTags(t, r, state, map[string]interface{}{
+		"name": "instance-1",
+		"tags": map[string]interface{}{"app": "web"},
+	}); !diff.Empty() {
+		t.Fatalf("expected an empty plan, got %#v", diff.Attributes)
+	}
+}
+
+func TestWrapResourceTags_withoutDefaultTags(t *testing.T) {
+	cloud := newTestTagsCloud()
+	r := cloud.resource(&providerSettings{})
+
+	config := map[string]interface{}{
+		"name": "instance-1",
+		"tags": map[string]interface{}{"app": "web"},
+	}
+	state := testApplyTags(t, r, nil, config)
+	expectStateTags(t, r, state, "tags", map[string]string{"app": "web"})
+	expectStateTags(t, r, state, "tags_all", map[string]string{"app": "web"})
+
+	// tags which are added outside of Terraform are reported as a change
+	cloud.setTags("instance-1", map[string]string{"app": "web", "manual": "true"})
+	state = testRefreshTags(t, r, state)
+	if diff := testPlanTags(t, r, state, config); diff.Attributes["tags.manual"] == nil {
+		t.Fatalf("expected the manual tag to be removed, got %#v", diff.Attributes)
+	}
+}
+
+func TestWrapResourceTags_ignoreTags(t *testing.T) {
+	cloud := newTestTagsCloud()
+	r := cloud.resource(&providerSettings{
+		defaultTags: map[string]interface{}{"owner": "team-a"},
+		ignoreTags: &ignoreTags{
+			keys:     map[string]bool{"CBR_backup": true},
+			prefixes: []string{"cmdb:"},
+		},
+	})
+
+	config := map[string]interface{}{
+		"name": "instance-1",
+		"tags": map[string]interface{}{"app": "web"},
+	}
+	state := testApplyTags(t, r, nil, config)
+
+	// the tags are added outside of Terraform
+	cloud.setTags("instance-1", map[string]string{
+		"app": "web", "owner": "team-a", "CBR_backup": "daily", "cmdb:id": "42",
+	})
+	state = testRefreshTags(t, r, state)
+	expectStateTags(t, r, state, "tags_all", map[string]string{"app": "web", "owner": "team-a"})
+	if diff := testPlanTags(t, r, state, config); !diff.Empty() {
+		t.Fatalf("expected an empty plan, got %#v", diff.Attributes)
+	}
+
+	// the ignored tags are kept on update
+	config["tags"] = map[string]interface{}{"app": "api"}
+	state = testApplyTags(t, r, state, config)
+	expectStateTags(t, r, state, "tags_all", map[string]string{"app": "api", "owner": "team-a"})
+	expectTagsMap(t, cloud.tags("instance-1"), map[string]string{
+		"app": "api", "owner": "team-a", "CBR_backup": "daily", "cmdb:id": "42",
+	})
+}
+
+func TestExpandProviderSettings(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"check_quotas": true,
+		"default_tags": []interface{}{
+			map[string]interface{}{
+				"tags": map[string]interface{}{"owner": "team-a"},
+			},
+		},
+		"ignore_tags": []interface{}{
+			map[string]interface{}{
+				"keys":         []interface{}{"CBR_backup"},
+				"key_prefixes": []interface{}{"cmdb:"},
+			},
+		},
+	})
+
+	settings := expandProviderSettings(d)
+	if !settings.checkQuotas {
+		t.Fatalf("expected check_quotas to be set")
+	}
+	if settings.defaultTags["owner"] != "team-a" {
+		t.Fatalf("expected the default tags, got %v", settings.defaultTags)
+	}
+	for key, ignored := range map[string]bool{"CBR_backup": true, "cmdb:id": true, "app": false} {
+		if settings.ignoreTags.ignored(key) != ignored {
+			t.Fatalf("expected the tag %s to be ignored: %t", key, ignored)
+		}
+	}
+}
+
+// testTagsCloud stores the tags of the test resources.
+type testTagsCloud struct {
+	mu        sync.Mutex
+	resources map[string]map[string]string
+}
+
+func newTestTagsCloud() *testTagsCloud {
+	return &testTagsCloud{resources: make(map[string]map[string]string)}
+}
+
+func (c *testTagsCloud) tags(id string) map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.resources[id]
+}
+
+func (c *testTagsCloud) setTags(id string, tags map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resources[id] = tags
+}
+
+// resource returns a wrapped resource which manages its tags like the shared tag helpers.
+func (c *testTagsCloud) resource(settings *providerSettings) *schema.Resource {
+	r := &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			c.setTags(d.Get("name").(string), expandTestTags(d.Get("tags")))
+			d.SetId(d.Get("name").(string))
+			return nil
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := d.Set("name", d.Id()); err != nil {
+				return diag.FromErr(err)
+			}
+			return diag.FromErr(d.Set("tags", c.tags(d.Id())))
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if d.HasChange("tags") {
+				oldTags, newTags := d.GetChange("tags")
+				result := make(map[string]string)
+				for k, v := range c.tags(d.Id()) {
+					if _, ok := oldTags.(map[string]interface{})[k]; !ok {
+						result[k] = v
+					}
+				}
+				for k, v := range expandTestTags(newTags) {
+					result[k] = v
+				}
+				c.setTags(d.Id(), result)
+			}
+			return nil
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"tags": tagsSchema(),
+		},
+	}
+	wrapResourceTags(r, settings)
+	return r
+}
+
+func expandTestTags(raw interface{}) map[string]string {
+	tags := make(map[string]string)
+	for k, v := range raw.(map[string]interface{}) {
+		tags[k] = v.(string)
+	}
+	return tags
+}
+
+func testPlanTags(t *testing.T, r *schema.Resource, state *terraform.InstanceState,
+	raw map[string]interface{}) *terraform.InstanceDiff {
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return diff
+}
+
+func testApplyTags(t *testing.T, r *schema.Resource, state *terraform.InstanceState,
+	raw map[string]interface{}) *terraform.InstanceState {
+	diff := testPlanTags(t, r, state, raw)
+	if diff.Empty() {
+		return state
+	}
+	state, diags := r.Apply(context.Background(), state, diff, nil)
+	expectNoWarnings(t, diags)
+	return state
+}
+
+func testRefreshTags(t *testing.T, r *schema.Resource, state *terraform.InstanceState) *terraform.InstanceState {
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), state, nil)
+	expectNoWarnings(t, diags)
+	return state
+}
+
+func expectStateTags(t *testing.T, r *schema.Resource, state *terraform.InstanceState, key string,
+	expected map[string]string) {
+	t.Helper()
+	expectTagsMap(t, expandTestTags(r.Data(state).Get(key)), expected)
+}
+
+func expectTagsMap(t *testing.T, tags, expected map[string]string) {
+	t.Helper()
+	if len(tags) != len(expected) {
+		t.Fatalf("expected tags %v, got %v", expected, tags)
+	}
+	for k, v := range expected {
+		if tags[k] != v {
+			t.Fatalf("expected tags %v, got %v", expected, tags)
+		}
+	}
+}
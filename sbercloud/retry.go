@@ -0,0 +1,251 @@
+package sbercloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const (
+	defaultRetryMinWait  = "1s"
+	defaultRetryMaxWait  = "30s"
+	defaultRetryDeadline = "5m"
+)
+
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// gatewayStatusCodes are returned when the request may have reached the service, so
+// they are only retried for the idempotent methods, and never for a create by POST.
+var gatewayStatusCodes = map[int]bool{
+	http.StatusBadGateway:     true,
+	http.StatusGatewayTimeout: true,
+}
+
+// idempotentMethods are the methods which can be sent again without side effects.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodHead:   true,
+	http.MethodPut:    true,
+	http.MethodDelete: true,
+}
+
+// retryPolicy describes how the API requests are retried on throttling and transient errors.
+type retryPolicy struct {
+	maxRetries  int
+	minWait     time.Duration
+	maxWait     time.Duration
+	jitter      bool
+	deadline    time.Duration
+	statusCodes map[int]bool
+}
+
+// expandRetryPolicy returns the retry policy of the provider, or nil if the retry block is not set.
+func expandRetryPolicy(d *schema.ResourceData) (*retryPolicy, error) {
+	raw := d.Get("retry").([]interface{})
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	// the block is set without any arguments
+	retry, _ := raw[0].(map[string]interface{})
+	if retry == nil {
+		retry = map[string]interface{}{
+			"min_wait": defaultRetryMinWait,
+			"max_wait": defaultRetryMaxWait,
+			"jitter":   true,
+			"deadline": defaultRetryDeadline,
+		}
+	}
+
+	policy := &retryPolicy{
+		maxRetries:  d.Get("max_retries").(int),
+		jitter:      retry["jitter"].(bool),
+		statusCodes: make(map[int]bool),
+	}
+
+	var err error
+	durations := map[string]*time.Duration{
+		"min_wait": &policy.minWait,
+		"max_wait": &policy.maxWait,
+		"deadline": &policy.deadline,
+	}
+	for key, value := range durations {
+		if *value, err = time.ParseDuration(retry[key].(string)); err != nil {
+			return nil, fmt.Errorf("invalid retry %s: %s", key, err)
+		}
+	}
+	if policy.minWait > policy.maxWait {
+		return nil, fmt.Errorf("the retry min_wait %s must not be greater than max_wait %s", policy.minWait, policy.maxWait)
+	}
+
+	codes := defaultRetryableStatusCodes
+	if v, ok := retry["retryable_status_codes"].(*schema.Set); ok && v.Len() > 0 {
+		codes = make([]int, 0, v.Len())
+		for _, code := range v.List() {
+			codes = append(codes, code.(int))
+		}
+	}
+	for _, code := range codes {
+		policy.statusCodes[code] = true
+	}
+
+	return policy, nil
+}
+
+func validateDuration(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a duration such as \"30s\" or \"2m\": %s", k, err))
+	}
+	return
+}
+
+// backoff returns the wait time before the retry: it grows exponentially from min_wait
+// up to max_wait, and is spread between min_wait and this value with jitter.
+func (p *retryPolicy) backoff(retry int) time.Duration {
+	wait := p.maxWait
+	if retry < 32 {
+		if w := p.minWait << uint(retry); w > 0 && w < p.maxWait {
+			wait = w
+		}
+	}
+
+	if p.jitter && wait > p.minWait {
+		wait = p.minWait + time.Duration(rand.Int63n(int64(wait-p.minWait)+1))
+	}
+	return wait
+}
+
+// installRetryPolicy replaces the retries of the clients with the retry policy.
+func installRetryPolicy(c *config.Config, policy *retryPolicy) {
+	for _, client := range []*golangsdk.ProviderClient{c.HwClient, c.DomainClient} {
+		if client == nil {
+			continue
+		}
+
+		transport := client.HTTPClient.Transport
+		if lrt, ok := transport.(*config.LogRoundTripper); ok {
+			// the connection errors are retried by the policy
+			lrt.MaxRetries = 0
+		}
+		client.HTTPClient.Transport = &retryRoundTripper{rt: transport, policy: policy}
+		client.RetryBackoffFunc = nil
+	}
+}
+
+// retryRoundTripper retries the requests which fail with a connection error or
+// a retryable status code. The connection errors of the non-idempotent requests are
+// only retried when the request was not written, as the service may have received it.
+type retryRoundTripper struct {
+	rt     http.RoundTripper
+	policy *retryPolicy
+}
+
+func (t *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.policy.deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.policy.deadline)
+		defer cancel()
+	}
+
+	for retry := 0; ; retry++ {
+		attempt, err := cloneRequest(req, retry)
+		if err != nil {
+			return nil, err
+		}
+
+		var written bool
+		trace := &httptrace.ClientTrace{
+			WroteRequest: func(info httptrace.WroteRequestInfo) {
+				written = written || info.Err == nil
+			},
+		}
+		attempt = attempt.WithContext(httptrace.WithClientTrace(attempt.Context(), trace))
+
+		resp, err := t.rt.RoundTrip(attempt)
+		if !t.retryable(req, resp, err, written) || retry >= t.policy.maxRetries || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		wait := t.policy.backoff(retry)
+		if resp != nil {
+			if after := retryAfter(resp); after > wait && after <= t.policy.maxWait {
+				wait = after
+			}
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			log.Printf("[DEBUG] not retrying %s %s, the retry deadline is reached", req.Method, req.URL)
+			return resp, err
+		}
+
+		reason := "a connection error"
+		if resp != nil {
+			reason = fmt.Sprintf("status code %d", resp.StatusCode)
+			// read the body so the connection can be reused
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		log.Printf("[DEBUG] retrying %s %s in %s after %s, retry %d of %d",
+			req.Method, req.URL, wait, reason, retry+1, t.policy.maxRetries)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("retrying %s %s: %s", req.Method, req.URL, ctx.Err())
+		}
+	}
+}
+
+func (t *retryRoundTripper) retryable(req *http.Request, resp *http.Response, err error, written bool) bool {
+	if err != nil {
+		return !written || idempotentMethods[req.Method]
+	}
+	if gatewayStatusCodes[resp.StatusCode] && !idempotentMethods[req.Method] {
+		return false
+	}
+	return t.policy.statusCodes[resp.StatusCode]
+}
+
+// cloneRequest returns the request to send, the body is read again for the retries.
+func cloneRequest(req *http.Request, retry int) (*http.Request, error) {
+	if retry == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("the body of %s %s can not be sent again", req.Method, req.URL)
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	attempt := req.Clone(req.Context())
+	attempt.Body = body
+	return attempt, nil
+}
+
+// retryAfter returns the wait time of the Retry-After header in seconds.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
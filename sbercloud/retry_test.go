@@ -0,0 +1,436 @@
+package sbercloud
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// testFlakyServer answers the requests with the given status codes in turn,
+// and with 200 once they are used up.
+type testFlakyServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+}
+
+func newTestFlakyServer(t *testing.T, statuses ...int) *testFlakyServer {
+	s := &testFlakyServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		s.mu.Lock()
+		s.bodies = append(s.bodies, string(body))
+		status := http.StatusOK
+		if len(s.statuses) > 0 {
+			status, s.statuses = s.statuses[0], s.statuses[1:]
+		}
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testFlakyServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.bodies...)
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func testRetryPolicy(maxRetries int) *retryPolicy {
+	policy := &retryPolicy{
+		maxRetries:  maxRetries,
+		minWait:     time.Millisecond,
+		maxWait:     5 * time.Millisecond,
+		deadline:    time.Minute,
+		statusCodes: make(map[int]bool),
+	}
+	for _, code := range defaultRetryableStatusCodes {
+		policy.statusCodes[code] = true
+	}
+	return policy
+}
+
+func testRetryClient(policy *retryPolicy) *http.Client {
+	return &http.Client{
+		Transport: &retryRoundTripper{rt: http.DefaultTransport, policy: policy},
+	}
+}
+
+func TestRetryRoundTripper_retryableStatusCodes(t *testing.T) {
+	server := newTestFlakyServer(t, 429, 502, 503, 504)
+
+	resp, err := testRetryClient(testRetryPolicy(5)).Get(server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", resp.StatusCode)
+	}
+	if n := len(server.requests()); n != 5 {
+		t.Fatalf("expected 5 requests, got %d", n)
+	}
+}
+
+func TestRetryRoundTripper_nonRetryableStatusCode(t *testing.T) {
+	server := newTestFlakyServer(t, 500, 503)
+
+	resp, err := testRetryClient(testRetryPolicy(5)).Get(server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected status code 500, got %d", resp.StatusCode)
+	}
+	if n := len(server.requests()); n != 1 {
+		t.Fatalf("expected 1 request, got %d", n)
+	}
+}
+
+func TestRetryRoundTripper_customStatusCodes(t *testing.T) {
+	server := newTestFlakyServer(t, 500, 429)
+	policy := testRetryPolicy(5)
+	policy.statusCodes = map[int]bool{500: true}
+
+	resp, err := testRetryClient(policy).Get(server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status code 429, got %d", resp.StatusCode)
+	}
+	if n := len(server.requests()); n != 2 {
+		t.Fatalf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestRetryRoundTripper_maxRetries(t *testing.T) {
+	server := newTestFlakyServer(t, 503, 503, 503, 503)
+
+	resp, err := testRetryClient(testRetryPolicy(2)).Get(server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected status code 503, got %d", resp.StatusCode)
+	}
+	if n := len(server.requests()); n != 3 {
+		t.Fatalf("expected 3 requests, got %d", n)
+	}
+}
+
+func TestRetryRoundTripper_body(t *testing.T) {
+	server := newTestFlakyServer(t, 502, 504)
+
+	req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"name":"test"}`))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp, err := testRetryClient(testRetryPolicy(5)).Do(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	bodies := server.requests()
+	if len(bodies) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(bodies))
+	}
+	for i, body := range bodies {
+		if body != `{"name":"test"}` {
+			t.Fatalf("expected the body to be sent again, got %q in request %d", body, i+1)
+		}
+	}
+}
+
+func TestRetryRoundTripper_nonIdempotent(t *testing.T) {
+	cases := []struct {
+		statuses []int
+		requests int
+	}{
+		// the create may have been done behind the gateway
+		{statuses: []int{502}, requests: 1},
+		{statuses: []int{504}, requests: 1},
+		// the request was rejected before it was processed
+		{statuses: []int{429, 503}, requests: 3},
+	}
+
+	for _, tc := range cases {
+		server := newTestFlakyServer(t, tc.statuses...)
+
+		resp, err := testRetryClient(testRetryPolicy(5)).Post(server.URL, "application/json",
+			strings.NewReader(`{"name":"test"}`))
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		resp.Body.Close()
+
+		if n := len(server.requests()); n != tc.requests {
+			t.Fatalf("expected %d requests for the status codes %v, got %d", tc.requests, tc.statuses, n)
+		}
+	}
+}
+
+func TestRetryRoundTripper_connectionError(t *testing.T) {
+	server := newTestFlakyServer(t)
+	url := server.URL
+	server.Close()
+
+	_, err := testRetryClient(testRetryPolicy(2)).Get(url)
+	if err == nil {
+		t.Fatalf("expected a connection error")
+	}
+}
+
+// newTestDroppingServer reads the requests and closes the connections without an answer.
+func newTestDroppingServer(t *testing.T, requests *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = ioutil.ReadAll(r.Body)
+		atomic.AddInt32(requests, 1)
+
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("err: %s", err)
+			return
+		}
+		conn.Close()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRetryRoundTripper_connectionErrorAfterWrite(t *testing.T) {
+	cases := []struct {
+		method   string
+		requests int32
+	}{
+		// the create may have been done before the connection was lost
+		{method: http.MethodPost, requests: 1},
+		{method: http.MethodPut, requests: 3},
+	}
+
+	for _, tc := range cases {
+		var requests int32
+		server := newTestDroppingServer(t, &requests)
+
+		req, err := http.NewRequest(tc.method, server.URL, strings.NewReader(`{"name":"test"}`))
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if _, err := testRetryClient(testRetryPolicy(2)).Do(req); err == nil {
+			t.Fatalf("expected a connection error for %s", tc.method)
+		}
+
+		if n := atomic.LoadInt32(&requests); n != tc.requests {
+			t.Fatalf("expected %d requests for %s, got %d", tc.requests, tc.method, n)
+		}
+	}
+}
+
+func TestRetryRoundTripper_connectionErrorBeforeWrite(t *testing.T) {
+	var attempts int32
+	policy := testRetryPolicy(2)
+	client := &http.Client{
+		Transport: &retryRoundTripper{
+			rt: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&attempts, 1)
+				return nil, errors.New("connection refused")
+			}),
+			policy: policy,
+		},
+	}
+
+	_, err := client.Post("http://127.0.0.1:1", "application/json", strings.NewReader(`{"name":"test"}`))
+	if err == nil {
+		t.Fatalf("expected a connection error")
+	}
+	if n := atomic.LoadInt32(&attempts); n != 3 {
+		t.Fatalf("expected the request which was not written to be retried, got %d attempts", n)
+	}
+}
+
+func TestRetryRoundTripper_deadline(t *testing.T) {
+	server := newTestFlakyServer(t, 503, 503)
+	policy := testRetryPolicy(5)
+	policy.minWait = time.Second
+	policy.maxWait = time.Second
+	policy.deadline = 100 * time.Millisecond
+
+	start := time.Now()
+	resp, err := testRetryClient(policy).Get(server.URL)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected status code 503, got %d", resp.StatusCode)
+	}
+	if n := len(server.requests()); n != 1 {
+		t.Fatalf("expected no retry beyond the deadline, got %d requests", n)
+	}
+	if elapsed := time.Since(start); elapsed > policy.minWait {
+		t.Fatalf("expected to give up without waiting, took %s", elapsed)
+	}
+}
+
+func TestRetryRoundTripper_contextCancelled(t *testing.T) {
+	server := newTestFlakyServer(t, 429, 429)
+	policy := testRetryPolicy(5)
+	policy.minWait = time.Minute
+	policy.maxWait = time.Minute
+	policy.deadline = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(100*time.Millisecond, cancel)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	start := time.Now()
+	_, err := testRetryClient(policy).Do(req)
+	if err == nil {
+		t.Fatalf("expected an error when the context is done")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("expected to stop waiting when the context is done, took %s", elapsed)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := &retryPolicy{minWait: time.Second, maxWait: 10 * time.Second}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+		10 * time.Second, 10 * time.Second}
+	for retry, wait := range expected {
+		if got := policy.backoff(retry); got != wait {
+			t.Fatalf("expected the wait time %s of retry %d, got %s", wait, retry, got)
+		}
+	}
+	if got := policy.backoff(100); got != policy.maxWait {
+		t.Fatalf("expected the wait time to be capped at %s, got %s", policy.maxWait, got)
+	}
+
+	policy.jitter = true
+	for retry := 0; retry < 10; retry++ {
+		if got := policy.backoff(retry); got < policy.minWait || got > policy.maxWait {
+			t.Fatalf("expected the wait time between %s and %s, got %s", policy.minWait, policy.maxWait, got)
+		}
+	}
+}
+
+func TestProvider_retry(t *testing.T) {
+	iam := newTestIAMServer(t)
+	server := newTestFlakyServer(t, 429, 503)
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"region":      "ru-moscow-1",
+		"auth_url":    iam.URL + "/v3",
+		"access_key":  "automation-ak",
+		"secret_key":  "automation-sk",
+		"project_id":  testIAMProjectID,
+		"domain_id":   testIAMDomainID,
+		"max_retries": 3,
+		"retry": []interface{}{
+			map[string]interface{}{
+				"min_wait": "1ms",
+				"max_wait": "5ms",
+			},
+		},
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	conf := meta.(*config.Config)
+	if _, ok := conf.HwClient.HTTPClient.Transport.(*retryRoundTripper); !ok {
+		t.Fatalf("expected the retry policy to be installed")
+	}
+	if conf.HwClient.RetryBackoffFunc != nil {
+		t.Fatalf("expected the retries of the SDK to be replaced by the retry policy")
+	}
+
+	client := golangsdk.ServiceClient{ProviderClient: conf.HwClient, Endpoint: server.URL + "/"}
+	if _, err := client.Get(client.ServiceURL("test"), nil, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if n := len(server.requests()); n != 3 {
+		t.Fatalf("expected 3 requests, got %d", n)
+	}
+}
+
+func TestProvider_retryInvalidDuration(t *testing.T) {
+	raw := map[string]interface{}{
+		"retry": []interface{}{
+			map[string]interface{}{
+				"max_wait": "30",
+			},
+		},
+	}
+
+	if !hasProviderConfigError(raw, "retry.0.max_wait") {
+		t.Fatalf("expected an error for the invalid max_wait")
+	}
+}
+
+func TestProvider_stopContext(t *testing.T) {
+	iam := newTestIAMServer(t)
+
+	stop, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	provider := Provider()
+	diags := provider.Configure(context.WithValue(context.Background(), schema.StopContextKey, stop),
+		terraform.NewResourceConfigRaw(map[string]interface{}{
+			"region":     "ru-moscow-1",
+			"auth_url":   iam.URL + "/v3",
+			"access_key": "automation-ak",
+			"secret_key": "automation-sk",
+			"project_id": testIAMProjectID,
+			"domain_id":  testIAMDomainID,
+			"endpoints": map[string]interface{}{
+				"iam": iam.URL,
+			},
+		}))
+	if diags.HasError() {
+		t.Fatalf("err: %v", diags)
+	}
+	if conf := provider.Meta().(*config.Config); conf.HwClient.Context != stop {
+		t.Fatalf("expected the requests to be sent with the stop context of the provider")
+	}
+}
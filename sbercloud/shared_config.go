@@ -0,0 +1,191 @@
+package sbercloud
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+const (
+	defaultSharedConfigFile = "~/.sbercloud/config"
+	defaultProfile          = "default"
+)
+
+// sharedConfigKeys are the provider arguments which can be read from a profile
+// of the shared configuration file.
+var sharedConfigKeys = []string{
+	"access_key", "secret_key", "region", "project_name", "account_name",
+	"agency_name", "agency_domain_name", "delegated_project",
+}
+
+// sharedConfigProfile holds the settings of a profile in the shared configuration file.
+type sharedConfigProfile map[string]string
+
+// loadSharedConfigProfile reads the profile from the shared configuration file.
+// The file can either be a JSON document:
+//
+//	{
+//	  "profiles": {
+//	    "default": {
+//	      "access_key": "...",
+//	      "secret_key": "..."
+//	    }
+//	  }
+//	}
+//
+// or an INI file which has a section per profile:
+//
+//	[default]
+//	access_key = ...
+//	secret_key = ...
+//
+// A missing file is only an error when the file or the profile was set explicitly.
+func loadSharedConfigProfile(d *schema.ResourceData) (sharedConfigProfile, error) {
+	path := d.Get("shared_config_file").(string)
+	profile := d.Get("profile").(string)
+
+	explicit := path != "" || profile != ""
+	if path == "" {
+		path = defaultSharedConfigFile
+	}
+	if profile == "" {
+		profile = defaultProfile
+	}
+
+	filename, err := homedir.Expand(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error expanding the shared config file path %s: %s", path, err)
+	}
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return sharedConfigProfile{}, nil
+		}
+		return nil, fmt.Errorf("Error reading the shared config file %s: %s", path, err)
+	}
+
+	var profiles map[string]sharedConfigProfile
+	if strings.HasPrefix(strings.TrimSpace(string(content)), "{") {
+		profiles, err = parseSharedConfigJSON(content)
+	} else {
+		profiles, err = parseSharedConfigINI(content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing the shared config file %s: %s", path, err)
+	}
+
+	settings, ok := profiles[profile]
+	if !ok {
+		if !explicit {
+			return sharedConfigProfile{}, nil
+		}
+		return nil, fmt.Errorf("the profile %q was not found in the shared config file %s", profile, path)
+	}
+
+	for key := range settings {
+		if !isSharedConfigKey(key) {
+			return nil, fmt.Errorf("the profile %q contains an unsupported key %q, supported keys are: %s",
+				profile, key, strings.Join(sharedConfigKeys, ", "))
+		}
+	}
+
+	log.Printf("[DEBUG] use the profile %q of the shared config file %s", profile, path)
+	return settings, nil
+}
+
+func parseSharedConfigJSON(content []byte) (map[string]sharedConfigProfile, error) {
+	var file struct {
+		Profiles map[string]sharedConfigProfile `json:"profiles"`
+	}
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+	return file.Profiles, nil
+}
+
+func parseSharedConfigINI(content []byte) (map[string]sharedConfigProfile, error) {
+	profiles := make(map[string]sharedConfigProfile)
+
+	var current sharedConfigProfile
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for num := 1; scanner.Scan(); num++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := profiles[name]; !ok {
+				profiles[name] = make(sharedConfigProfile)
+			}
+			current = profiles[name]
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("line %d: expected key = value, got %q", num, line)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: the key %q is not in a profile section", num, strings.TrimSpace(parts[0]))
+		}
+		current[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return profiles, scanner.Err()
+}
+
+func isSharedConfigKey(key string) bool {
+	for _, k := range sharedConfigKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// providerAuthKeys are the provider arguments which choose the authentication method.
+var providerAuthKeys = []string{"access_key", "secret_key", "password", "token"}
+
+// profileCredentials returns the profile without its credentials when an authentication
+// method is set in the provider block or the environment variables, so that the AK/SK of
+// the profile never override the method chosen by the user.
+func profileCredentials(d *schema.ResourceData, profile sharedConfigProfile) sharedConfigProfile {
+	for _, key := range providerAuthKeys {
+		if v, ok := d.GetOk(key); ok && v.(string) != "" {
+			result := make(sharedConfigProfile, len(profile))
+			for k, v := range profile {
+				if k != "access_key" && k != "secret_key" {
+					result[k] = v
+				}
+			}
+			return result
+		}
+	}
+	return profile
+}
+
+// getProviderString returns the value of the provider argument. The explicit argument
+// and its environment variable take precedence over the value in the profile.
+func getProviderString(d *schema.ResourceData, profile sharedConfigProfile, key string) string {
+	if v, ok := d.GetOk(key); ok && v.(string) != "" {
+		return v.(string)
+	}
+	return profile[key]
+}
+
+// isProviderStringSet returns whether the argument is set in the provider block
+// rather than through its environment variable or the shared config profile.
+func isProviderStringSet(d *schema.ResourceData, key, env string) bool {
+	v, ok := d.GetOk(key)
+	return ok && v.(string) != os.Getenv(env)
+}
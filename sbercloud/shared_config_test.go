@@ -0,0 +1,265 @@
+package sbercloud
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+const testSharedConfigINI = `
+# engineering accounts
+[default]
+access_key = default-ak
+secret_key = default-sk
+region     = ru-moscow-1
+
+[tenant]
+access_key         = tenant-ak
+secret_key         = tenant-sk
+region             = ru-moscow-1
+project_name       = ru-moscow-1_tenant
+account_name       = automation-account
+agency_name        = tenant_admin
+agency_domain_name = tenant-account
+`
+
+const testSharedConfigJSON = `{
+  "profiles": {
+    "default": {
+      "access_key": "default-ak",
+      "secret_key": "default-sk",
+      "region": "ru-moscow-1"
+    },
+    "tenant": {
+      "access_key": "tenant-ak",
+      "secret_key": "tenant-sk",
+      "project_name": "ru-moscow-1_tenant"
+    }
+  }
+}`
+
+func TestLoadSharedConfigProfile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		return path
+	}
+	iniFile := writeFile("config", testSharedConfigINI)
+	jsonFile := writeFile("config.json", testSharedConfigJSON)
+	badKeyFile := writeFile("bad_key", "[default]\npassword = secret\n")
+
+	cases := map[string]struct {
+		raw         map[string]interface{}
+		expected    sharedConfigProfile
+		expectedErr string
+	}{
+		"INI default profile": {
+			raw: map[string]interface{}{"shared_config_file": iniFile},
+			expected: sharedConfigProfile{
+				"access_key": "default-ak", "secret_key": "default-sk", "region": "ru-moscow-1",
+			},
+		},
+		"INI named profile": {
+			raw: map[string]interface{}{"shared_config_file": iniFile, "profile": "tenant"},
+			expected: sharedConfigProfile{
+				"access_key": "tenant-ak", "secret_key": "tenant-sk", "region": "ru-moscow-1",
+				"project_name": "ru-moscow-1_tenant", "account_name": "automation-account",
+				"agency_name": "tenant_admin", "agency_domain_name": "tenant-account",
+			},
+		},
+		"JSON named profile": {
+			raw: map[string]interface{}{"shared_config_file": jsonFile, "profile": "tenant"},
+			expected: sharedConfigProfile{
+				"access_key": "tenant-ak", "secret_key": "tenant-sk", "project_name": "ru-moscow-1_tenant",
+			},
+		},
+		"unknown profile": {
+			raw:         map[string]interface{}{"shared_config_file": iniFile, "profile": "unknown"},
+			expectedErr: `the profile "unknown" was not found`,
+		},
+		"missing file": {
+			raw:         map[string]interface{}{"shared_config_file": filepath.Join(dir, "missing")},
+			expectedErr: "Error reading the shared config file",
+		},
+		"unsupported key": {
+			raw:         map[string]interface{}{"shared_config_file": badKeyFile},
+			expectedErr: `unsupported key "password"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, Provider().Schema, tc.raw)
+			profile, err := loadSharedConfigProfile(d)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected an error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if len(profile) != len(tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, profile)
+			}
+			for k, v := range tc.expected {
+				if profile[k] != v {
+					t.Fatalf("expected %s to be %q, got %q", k, v, profile[k])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadSharedConfigProfile_defaultFileMissing(t *testing.T) {
+	setTestHomeDir(t)
+	t.Setenv("SBC_SHARED_CONFIG_FILE", "")
+	t.Setenv("SBC_PROFILE", "")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+	profile, err := loadSharedConfigProfile(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(profile) != 0 {
+		t.Fatalf("expected an empty profile, got %v", profile)
+	}
+}
+
+func TestProvider_sharedConfigPrecedence(t *testing.T) {
+	t.Setenv("SBC_ACCESS_KEY", "")
+	t.Setenv("SBC_SECRET_KEY", "")
+	t.Setenv("SBC_REGION_NAME", "")
+	t.Setenv("SBC_PROJECT_NAME", "ru-moscow-1_env")
+
+	iam := newTestIAMServer(t)
+	path := filepath.Join(t.TempDir(), "config")
+	if err := ioutil.WriteFile(path, []byte(testSharedConfigINI), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"shared_config_file": path,
+		"profile":            "tenant",
+		"auth_url":           iam.URL + "/v3",
+		"agency_name":        "explicit_agency",
+		"max_retries":        0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	conf := meta.(*config.Config)
+	actual := map[string]string{
+		"access_key":   conf.AccessKey,
+		"region":       conf.Region,
+		"project_name": conf.TenantName,
+		"account_name": conf.DomainName,
+		"agency_name":  conf.AgencyName,
+	}
+	for k, v := range map[string]string{
+		"access_key":   "tenant-ak",
+		"region":       "ru-moscow-1",
+		"project_name": "ru-moscow-1_env",
+		"account_name": "automation-account",
+		"agency_name":  "explicit_agency",
+	} {
+		if actual[k] != v {
+			t.Fatalf("expected %s to be %q, got %q", k, v, actual[k])
+		}
+	}
+}
+
+func TestProvider_sharedConfigExplicitAuth(t *testing.T) {
+	t.Setenv("SBC_ACCESS_KEY", "")
+	t.Setenv("SBC_SECRET_KEY", "")
+	t.Setenv("SBC_ACCOUNT_NAME", "")
+	t.Setenv("SBC_DOMAIN_ID", "")
+
+	path := filepath.Join(t.TempDir(), "config")
+	if err := ioutil.WriteFile(path, []byte(testSharedConfigINI), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// the password is checked instead of being overridden by the AK/SK of the profile
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"shared_config_file": path,
+		"user_name":          "automation",
+		"password":           "my-password",
+	})
+	_, err := configureProvider(d, "0.12+compatible")
+	if err == nil || !strings.Contains(err.Error(), `one of "account_name" or "domain_id"`) {
+		t.Fatalf("expected the password authentication to be checked, got %v", err)
+	}
+
+	profile := profileCredentials(d, sharedConfigProfile{"access_key": "default-ak", "secret_key": "default-sk",
+		"region": "ru-moscow-1"})
+	if len(profile) != 1 || profile["region"] != "ru-moscow-1" {
+		t.Fatalf("expected only the credentials to be removed from the profile, got %v", profile)
+	}
+}
+
+func TestProvider_sharedConfigSecurityToken(t *testing.T) {
+	t.Setenv("SBC_ACCESS_KEY", "")
+	t.Setenv("SBC_SECRET_KEY", "")
+
+	iam := newTestIAMServer(t)
+	path := filepath.Join(t.TempDir(), "config")
+	if err := ioutil.WriteFile(path, []byte(testSharedConfigINI), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"shared_config_file": path,
+		"auth_url":           iam.URL + "/v3",
+		"security_token":     "temporary-token",
+		"max_retries":        0,
+		"endpoints": map[string]interface{}{
+			"iam": iam.URL,
+		},
+	})
+	meta, err := configureProvider(d, "0.12+compatible")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	conf := meta.(*config.Config)
+	if conf.AccessKey != "default-ak" || conf.SecurityToken != "temporary-token" {
+		t.Fatalf("expected the temporary AK/SK of the profile, got %q", conf.AccessKey)
+	}
+}
+
+func TestProvider_regionRequired(t *testing.T) {
+	setTestHomeDir(t)
+	t.Setenv("SBC_REGION_NAME", "")
+	t.Setenv("SBC_SHARED_CONFIG_FILE", "")
+	t.Setenv("SBC_PROFILE", "")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+	_, err := configureProvider(d, "0.12+compatible")
+	if err == nil || !strings.Contains(err.Error(), `"region" must be specified`) {
+		t.Fatalf("expected a region error, got %v", err)
+	}
+}
+
+// setTestHomeDir points the home directory to an empty temporary directory,
+// so that no shared config file of the user is picked up.
+func setTestHomeDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	homedir.DisableCache = true
+	t.Cleanup(func() { homedir.DisableCache = false })
+}
@@ -56,6 +56,43 @@ func TestAccVpcV1_basic(t *testing.T) {
 	})
 }
 
+func TestAccVpcV1_defaultTags(t *testing.T) {
+	var vpc vpcs.Vpc
+
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "sbercloud_vpc.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckVpcV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcV1_defaultTags(rName, "terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcV1Exists(resourceName, &vpc),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "tags.owner", "terraform"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.foo", "bar"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.owner", "terraform"),
+				),
+			},
+			{
+				Config: testAccVpcV1_defaultTags(rName, "terraform_updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcV1Exists(resourceName, &vpc),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags.owner", "terraform_updated"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.owner", "terraform_updated"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccVpcV1_WithEpsId(t *testing.T) {
 	var vpc vpcs.Vpc
 
@@ -149,6 +186,28 @@ resource "sbercloud_vpc" "test" {
 `, rName)
 }
 
+func testAccVpcV1_defaultTags(rName, owner string) string {
+	return fmt.Sprintf(`
+provider "sbercloud" {
+  default_tags {
+    tags = {
+      foo   = "default"
+      owner = "%s"
+    }
+  }
+}
+
+resource "sbercloud_vpc" "test" {
+  name = "%s"
+  cidr = "192.168.0.0/16"
+
+  tags = {
+    foo = "bar"
+  }
+}
+`, owner, rName)
+}
+
 func testAccVpcV1_update(rName string) string {
 	return fmt.Sprintf(`
 resource "sbercloud_vpc" "test" {